@@ -0,0 +1,213 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+type Alert struct {
+	Rule  string                 `json:"rule"`
+	Kind  string                 `json:"kind"`
+	Pair  coingeckoapi.PricePair `json:"pair"`
+	Price decimal.Decimal        `json:"price"`
+	// threshold for above/below, price at window start for percent change
+	Reference decimal.Decimal `json:"reference"`
+	Message   string          `json:"message"`
+	Time      time.Time       `json:"time"`
+}
+
+type ruleState struct {
+	// fired and the condition still holds, no repeat until it clears
+	active    bool
+	lastFired time.Time
+}
+
+// evaluates rules against polled simple/price data
+type Engine struct {
+	client    *coingeckoapi.Client
+	interval  time.Duration
+	notifiers []Notifier
+	errs      chan error
+
+	mu      sync.Mutex
+	rules   map[string]*Rule
+	states  map[string]*ruleState
+	history map[coingeckoapi.PricePair]*history
+}
+
+func NewEngine(client *coingeckoapi.Client, interval time.Duration, notifiers ...Notifier) (*Engine, error) {
+	if interval <= 0 {
+		return nil, errors.New("engine interval must be positive")
+	}
+	return &Engine{
+		client:    client,
+		interval:  interval,
+		notifiers: notifiers,
+		errs:      make(chan error, 1),
+		rules:     make(map[string]*Rule),
+		states:    make(map[string]*ruleState),
+		history:   make(map[coingeckoapi.PricePair]*history),
+	}, nil
+}
+
+// replaces a rule with the same name
+func (e *Engine) AddRule(rule Rule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+	rule.Pair.ID = strings.ToLower(rule.Pair.ID)
+	rule.Pair.Vs = strings.ToLower(rule.Pair.Vs)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules[rule.Name] = &rule
+	e.states[rule.Name] = &ruleState{}
+	return nil
+}
+
+func (e *Engine) RemoveRule(name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.rules, name)
+	delete(e.states, name)
+}
+
+// failed polls and notifications, one is buffered and the rest dropped until it is read
+func (e *Engine) Errors() <-chan error {
+	return e.errs
+}
+
+// polls every interval until ctx is done
+func (e *Engine) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		if err := e.Poll(ctx); err != nil {
+			e.report(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// one round of fetching prices, evaluating rules and notifying
+func (e *Engine) Poll(ctx context.Context) error {
+	ids, vs := e.pairs()
+	if len(ids) == 0 {
+		return nil
+	}
+	prices, err := e.client.SimplePricesContext(ctx, ids, vs, nil)
+	if err != nil {
+		return err
+	}
+	for _, alert := range e.evaluate(prices, time.Now()) {
+		for _, n := range e.notifiers {
+			if err := n.Notify(ctx, alert); err != nil {
+				e.report(fmt.Errorf("notify %s: %w", alert.Rule, err))
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Engine) pairs() ([]string, []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	idSet := make(map[string]struct{})
+	vsSet := make(map[string]struct{})
+	for _, r := range e.rules {
+		idSet[r.Pair.ID] = struct{}{}
+		vsSet[r.Pair.Vs] = struct{}{}
+	}
+	return keys(idSet), keys(vsSet)
+}
+
+func (e *Engine) evaluate(prices coingeckoapi.SimplePricesResponse, now time.Time) []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// longest window per pair decides how much history to keep
+	keep := make(map[coingeckoapi.PricePair]time.Duration)
+	for _, r := range e.rules {
+		if r.Window > keep[r.Pair] {
+			keep[r.Pair] = r.Window
+		}
+	}
+	for pair, window := range keep {
+		price, ok := prices[pair.ID].Price[pair.Vs]
+		if !ok {
+			continue
+		}
+		h, ok := e.history[pair]
+		if !ok {
+			h = &history{}
+			e.history[pair] = h
+		}
+		h.add(now, price, window)
+	}
+
+	names := make([]string, 0, len(e.rules))
+	for name := range e.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var out []Alert
+	for _, name := range names {
+		r := e.rules[name]
+		price, ok := prices[r.Pair.ID].Price[r.Pair.Vs]
+		if !ok {
+			continue
+		}
+		state := e.states[name]
+		hit, ref, msg := r.check(price, e.history[r.Pair], now)
+		if !hit {
+			state.active = false
+			continue
+		}
+		if state.active {
+			continue
+		}
+		// held back by the cooldown, checked again on the next poll
+		if !state.lastFired.IsZero() && now.Sub(state.lastFired) < r.Cooldown {
+			continue
+		}
+		state.active = true
+		state.lastFired = now
+		out = append(out, Alert{
+			Rule:      r.Name,
+			Kind:      r.Kind,
+			Pair:      r.Pair,
+			Price:     price,
+			Reference: ref,
+			Message:   msg,
+			Time:      now,
+		})
+	}
+	return out
+}
+
+func (e *Engine) report(err error) {
+	select {
+	case e.errs <- err:
+	default:
+	}
+}
+
+func keys(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,36 @@
+package alerts
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type sample struct {
+	at    time.Time
+	price decimal.Decimal
+}
+
+// polled prices of one pair, oldest first
+type history struct {
+	samples []sample
+}
+
+func (h *history) add(at time.Time, price decimal.Decimal, keep time.Duration) {
+	h.samples = append(h.samples, sample{at: at, price: price})
+	cut := 0
+	for cut < len(h.samples)-1 && at.Sub(h.samples[cut+1].at) >= keep {
+		cut++
+	}
+	h.samples = h.samples[cut:]
+}
+
+// latest price at or before t, false if history doesn't reach back that far
+func (h *history) at(t time.Time) (decimal.Decimal, bool) {
+	for i := len(h.samples) - 1; i >= 0; i-- {
+		if !h.samples[i].at.After(t) {
+			return h.samples[i].price, true
+		}
+	}
+	return decimal.Zero, false
+}
@@ -0,0 +1,81 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// one line per alert
+type WriterNotifier struct {
+	W io.Writer
+}
+
+// writes to os.Stdout
+func NewStdoutNotifier() *WriterNotifier {
+	return &WriterNotifier{W: os.Stdout}
+}
+
+func (n *WriterNotifier) Notify(ctx context.Context, alert Alert) error {
+	_, err := fmt.Fprintf(n.W, "%s [%s] %s\n", alert.Time.Format(time.RFC3339), alert.Rule, alert.Message)
+	return err
+}
+
+// posts the alert as json
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL: url,
+		Client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("webhook status %d: %v", resp.StatusCode, string(msg))
+	}
+	return nil
+}
+
+// sends alerts on a channel, blocks until received or ctx is done
+type ChanNotifier chan Alert
+
+func (n ChanNotifier) Notify(ctx context.Context, alert Alert) error {
+	select {
+	case n <- alert:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,89 @@
+package alerts
+
+import (
+	"fmt"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+// rule kinds
+const (
+	// price goes above Threshold
+	KindAbove = "above"
+	// price goes below Threshold
+	KindBelow = "below"
+	// price moves Percent within Window, negative Percent for drops
+	KindPercentChange = "percent_change"
+	// price leaves the Lower - Upper band
+	KindBandExit = "band_exit"
+)
+
+type Rule struct {
+	// unique, used for cooldown and de-duplication
+	Name string
+	Pair coingeckoapi.PricePair
+	Kind string
+
+	Threshold decimal.Decimal
+	Percent   decimal.Decimal
+	Window    time.Duration
+	Lower     decimal.Decimal
+	Upper     decimal.Decimal
+
+	// no repeat of this rule within Cooldown after it fired
+	Cooldown time.Duration
+}
+
+func (r *Rule) validate() error {
+	switch r.Kind {
+	case KindAbove, KindBelow:
+	case KindPercentChange:
+		if r.Window <= 0 {
+			return fmt.Errorf("rule %s: window required", r.Name)
+		}
+		if r.Percent.IsZero() {
+			return fmt.Errorf("rule %s: percent required", r.Name)
+		}
+	case KindBandExit:
+		if !r.Lower.LessThan(r.Upper) {
+			return fmt.Errorf("rule %s: lower must be below upper", r.Name)
+		}
+	default:
+		return fmt.Errorf("rule %s: unknown kind %q", r.Name, r.Kind)
+	}
+	if r.Name == "" {
+		return fmt.Errorf("rule name required")
+	}
+	return nil
+}
+
+// whether the rule condition holds, with the reference price it was checked against
+func (r *Rule) check(price decimal.Decimal, h *history, now time.Time) (bool, decimal.Decimal, string) {
+	switch r.Kind {
+	case KindAbove:
+		return price.GreaterThan(r.Threshold), r.Threshold,
+			fmt.Sprintf("%s %s at %s, above %s", r.Pair.ID, r.Pair.Vs, price, r.Threshold)
+	case KindBelow:
+		return price.LessThan(r.Threshold), r.Threshold,
+			fmt.Sprintf("%s %s at %s, below %s", r.Pair.ID, r.Pair.Vs, price, r.Threshold)
+	case KindPercentChange:
+		ref, ok := h.at(now.Add(-r.Window))
+		if !ok || ref.IsZero() {
+			return false, decimal.Zero, ""
+		}
+		change := price.Sub(ref).Div(ref).Mul(decimal.NewFromInt(100))
+		hit := change.GreaterThanOrEqual(r.Percent)
+		if r.Percent.IsNegative() {
+			hit = change.LessThanOrEqual(r.Percent)
+		}
+		return hit, ref, fmt.Sprintf("%s %s moved %s%% in %s, %s => %s",
+			r.Pair.ID, r.Pair.Vs, change.StringFixed(2), r.Window, ref, price)
+	case KindBandExit:
+		hit := price.LessThan(r.Lower) || price.GreaterThan(r.Upper)
+		return hit, decimal.Zero, fmt.Sprintf("%s %s at %s, out of %s - %s",
+			r.Pair.ID, r.Pair.Vs, price, r.Lower, r.Upper)
+	}
+	return false, decimal.Zero, ""
+}
@@ -0,0 +1,115 @@
+package coingeckoapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// platforms are added a few times a month
+const platformsTTL = 24 * time.Hour
+
+type AssetPlatformResponse struct {
+	ID string `json:"id"`
+	// evm chain id, nil for non-evm platforms
+	ChainIdentifier *int64 `json:"chain_identifier"`
+	Name            string `json:"name"`
+	Shortname       string `json:"shortname"`
+	NativeCoinID    string `json:"native_coin_id"`
+}
+
+// filter ex => nft, empty for all platforms
+func (b *Client) AssetPlatforms(filter string) ([]AssetPlatformResponse, error) {
+	type opt struct {
+		Filter string `url:"filter,omitempty"`
+	}
+	input := opt{
+		Filter: filter,
+	}
+	res, err := b.do("spot", http.MethodGet, "asset_platforms", input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []AssetPlatformResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// evm chain id => platform, platforms without chain id are left out
+func ChainPlatforms(list []AssetPlatformResponse) map[int64]AssetPlatformResponse {
+	out := make(map[int64]AssetPlatformResponse)
+	for _, p := range list {
+		if p.ChainIdentifier == nil {
+			continue
+		}
+		out[*p.ChainIdentifier] = p
+	}
+	return out
+}
+
+// chainID ex => 137 gives polygon-pos, native coin matic-network
+// the platform list is cached inside the client for platformsTTL
+func (b *Client) PlatformByChainID(chainID int64) (*AssetPlatformResponse, error) {
+	list, err := b.cachedPlatforms()
+	if err != nil {
+		return nil, err
+	}
+	p, ok := ChainPlatforms(list)[chainID]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("no asset platform for chain id %d", chainID))
+	}
+	return &p, nil
+}
+
+func (b *Client) cachedPlatforms() ([]AssetPlatformResponse, error) {
+	b.platformsMu.Lock()
+	defer b.platformsMu.Unlock()
+	if b.platforms != nil && time.Since(b.platformsAt) < platformsTTL {
+		return b.platforms, nil
+	}
+	list, err := b.AssetPlatforms("")
+	if err != nil {
+		return nil, err
+	}
+	b.platforms = list
+	b.platformsAt = time.Now()
+	return list, nil
+}
+
+type ChainPrices struct {
+	Platform AssetPlatformResponse
+	// price of the native gas coin, zero value when the platform has none
+	Native SimplePriceQuote
+	// contract address, lower case => quote
+	Tokens SimplePricesResponse
+}
+
+// prices the native coin and token contracts of an evm chain, ex => chainID 137 with usdc and weth contracts
+// contracts can be empty, opt can be nil
+func (b *Client) ChainPricesContext(ctx context.Context, chainID int64, contracts, quoteCurrencies []string, opt *SimplePriceOptions) (*ChainPrices, error) {
+	p, err := b.PlatformByChainID(chainID)
+	if err != nil {
+		return nil, err
+	}
+	out := ChainPrices{Platform: *p, Tokens: SimplePricesResponse{}}
+	if p.NativeCoinID != "" {
+		prices, err := b.SimplePricesContext(ctx, []string{p.NativeCoinID}, quoteCurrencies, opt)
+		if err != nil {
+			return nil, err
+		}
+		out.Native = prices[strings.ToLower(p.NativeCoinID)]
+	}
+	if len(contracts) > 0 {
+		out.Tokens, err = b.TokenPriceContext(ctx, p.ID, contracts, quoteCurrencies, opt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &out, nil
+}
@@ -0,0 +1,85 @@
+package coingeckoapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// order options for Categories
+const (
+	CategoryOrderMarketCapDesc       = "market_cap_desc"
+	CategoryOrderMarketCapAsc        = "market_cap_asc"
+	CategoryOrderNameDesc            = "name_desc"
+	CategoryOrderNameAsc             = "name_asc"
+	CategoryOrderMarketCapChangeDesc = "market_cap_change_24h_desc"
+	CategoryOrderMarketCapChangeAsc  = "market_cap_change_24h_asc"
+)
+
+type CategoryListResponse struct {
+	CategoryID string `json:"category_id"`
+	Name       string `json:"name"`
+}
+
+type CategoryResponse struct {
+	ID                 string          `json:"id"`
+	Name               string          `json:"name"`
+	MarketCap          decimal.Decimal `json:"market_cap"`
+	MarketCapChange24h decimal.Decimal `json:"market_cap_change_24h"`
+	Content            string          `json:"content"`
+	Top3Coins          []string        `json:"top_3_coins"`
+	Volume24h          decimal.Decimal `json:"volume_24h"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+}
+
+// all the category ids and names, no market data
+func (b *Client) CategoriesList() ([]CategoryListResponse, error) {
+	res, err := b.do("spot", http.MethodGet, "coins/categories/list", nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []CategoryListResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// order ex => CategoryOrderMarketCapDesc, empty for default
+func (b *Client) Categories(order string) ([]CategoryResponse, error) {
+	type opt struct {
+		Order string `url:"order,omitempty"`
+	}
+	input := opt{
+		Order: order,
+	}
+	res, err := b.do("spot", http.MethodGet, "coins/categories", input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []CategoryResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// coin detail only carries category names, map them back to the ids from CategoriesList
+// names not found in list are skipped
+func CategoryIDs(names []string, list []CategoryListResponse) []string {
+	byName := make(map[string]string, len(list))
+	for _, c := range list {
+		byName[strings.ToLower(c.Name)] = c.CategoryID
+	}
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if id, ok := byName[strings.ToLower(name)]; ok {
+			out = append(out, id)
+		}
+	}
+	return out
+}
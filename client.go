@@ -1,11 +1,14 @@
 package coingeckoapi
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -14,8 +17,31 @@ import (
 
 var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
+// plan options for SetAPIKey
+const (
+	PlanPublic = ""
+	PlanDemo   = "demo"
+	PlanPro    = "pro"
+)
+
 type Client struct {
-	client *http.Client
+	client  *http.Client
+	apiKey  string
+	plan    string
+	baseURL string
+
+	stats    *requestStats
+	observer func(RequestInfo)
+
+	trendingMu sync.Mutex
+	trending   *TrendingResponse
+	trendingAt time.Time
+
+	supportedVs supportedVsCache
+
+	platformsMu sync.Mutex
+	platforms   []AssetPlatformResponse
+	platformsAt time.Time
 }
 
 func New() *Client {
@@ -24,44 +50,130 @@ func New() *Client {
 	}
 	return &Client{
 		client: hc,
+		stats:  newRequestStats(),
 	}
 }
 
+// plan ex => PlanDemo, PlanPro
+// pro keys are sent to the pro-api host
+func (c *Client) SetAPIKey(key, plan string) {
+	c.apiKey = key
+	c.plan = plan
+}
+
+func (c *Client) Plan() string {
+	return c.plan
+}
+
+// replaces the spot endpoint, ex => a gateway at http://localhost:8080/api/v3
+func (c *Client) SetBaseURL(url string) {
+	c.baseURL = strings.TrimRight(url, "/")
+}
+
 func (c *Client) do(product, method, path string, data interface{}, sign bool, stream bool) (response []byte, err error) {
+	return c.doContext(context.Background(), product, method, path, data, sign, stream)
+}
+
+func (c *Client) doContext(ctx context.Context, product, method, path string, data interface{}, sign bool, stream bool) (response []byte, err error) {
 	var ENDPOINT string
 	switch product {
 	case "spot":
 		ENDPOINT = "https://api.coingecko.com/api/v3"
+		if c.plan == PlanPro {
+			ENDPOINT = "https://pro-api.coingecko.com/api/v3"
+		}
+		if c.baseURL != "" {
+			ENDPOINT = c.baseURL
+		}
+	case "onchain":
+		ENDPOINT = "https://api.geckoterminal.com/api/v2"
+		if c.plan == PlanPro {
+			ENDPOINT = "https://pro-api.coingecko.com/api/v3/onchain"
+		}
 	default:
 		// pass
 	}
-	values, err := query.Values(data)
-	if err != nil {
-		return nil, err
+	// url.Values passes through as is
+	values, ok := data.(url.Values)
+	if !ok {
+		values, err = query.Values(data)
+		if err != nil {
+			return nil, err
+		}
 	}
 	payload := values.Encode()
 
 	var req *http.Request
 	if method == http.MethodGet {
-		req, err = http.NewRequest(method, fmt.Sprintf("%s/%s?%s", ENDPOINT, path, payload), nil)
+		req, err = http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s?%s", ENDPOINT, path, payload), nil)
 	} else {
-		req, err = http.NewRequest(method, fmt.Sprintf("%s/%s", ENDPOINT, path), strings.NewReader(payload))
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		req, err = http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", ENDPOINT, path), strings.NewReader(payload))
+		if err == nil {
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c.apiKey == "":
+		// pass
+	case c.plan == PlanPro:
+		req.Header.Add("x-cg-pro-api-key", c.apiKey)
+	default:
+		req.Header.Add("x-cg-demo-api-key", c.apiKey)
+	}
+	if product == "onchain" {
+		req.Header.Add("Accept", "application/json;version=20230302")
 	}
 	//req.Header.Add("Accept", "application/json")
+	start := time.Now()
 	resp, err := c.client.Do(req)
 	if err != nil {
+		c.stats.record(0)
+		c.observe(product, path, 0, start, err)
 		return nil, err
 	}
 	defer resp.Body.Close()
+	c.stats.record(resp.StatusCode)
 	response, err = ioutil.ReadAll(resp.Body)
+	if err == nil && resp.StatusCode != http.StatusOK {
+		err = &StatusError{Code: resp.StatusCode, Body: response}
+	}
+	c.observe(product, path, resp.StatusCode, start, err)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status %d: %v", resp.StatusCode, string(response))
+	return response, nil
+}
+
+func (c *Client) observe(product, path string, status int, start time.Time, err error) {
+	if c.observer == nil {
+		return
 	}
-	return response, err
+	c.observer(RequestInfo{
+		Product: product,
+		Path:    path,
+		Status:  status,
+		Latency: time.Since(start),
+		Err:     err,
+	})
+}
+
+// GET on any spot path, for callers that relay the raw json
+// path ex => simple/price
+func (c *Client) Raw(ctx context.Context, path string, values url.Values) ([]byte, error) {
+	return c.doContext(ctx, "spot", http.MethodGet, strings.TrimLeft(path, "/"), values, false, false)
+}
+
+// non 200 response from coingecko
+type StatusError struct {
+	Code int
+	Body []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status %d: %v", e.Code, string(e.Body))
 }
 
 func TimeFromUnixTimestampInt(raw interface{}) (time.Time, error) {
@@ -71,3 +183,28 @@ func TimeFromUnixTimestampInt(raw interface{}) (time.Time, error) {
 	}
 	return time.Unix(0, ts*int64(time.Millisecond)), nil
 }
+
+// unix timestamp in seconds, null or 0 left as zero time
+type UnixTime struct {
+	time.Time
+}
+
+func (t *UnixTime) UnmarshalJSON(data []byte) error {
+	var sec float64
+	if err := json.Unmarshal(data, &sec); err != nil {
+		return err
+	}
+	if sec == 0 {
+		t.Time = time.Time{}
+		return nil
+	}
+	t.Time = time.Unix(0, int64(sec*float64(time.Second)))
+	return nil
+}
+
+func (t UnixTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(fmt.Sprintf("%d", t.Unix())), nil
+}
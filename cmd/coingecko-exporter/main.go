@@ -0,0 +1,65 @@
+// coingecko-exporter polls tracked coins and serves them as prometheus gauges
+// on /metrics, together with request metrics of the client it polls with.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+)
+
+func main() {
+	addr := flag.String("addr", ":9101", "listen address")
+	coins := flag.String("coins", "bitcoin,ethereum", "coin ids to track, comma-separated")
+	vs := flag.String("vs", "usd", "quote currencies, comma-separated")
+	interval := flag.Duration("interval", time.Minute, "poll interval")
+	apiKey := flag.String("api-key", os.Getenv("COINGECKO_API_KEY"), "api key, defaults to $COINGECKO_API_KEY")
+	plan := flag.String("plan", os.Getenv("COINGECKO_PLAN"), "plan of the api key, demo or pro")
+	baseURL := flag.String("base-url", "", "replaces the api endpoint, ex => a gateway")
+	flag.Parse()
+	if *interval <= 0 {
+		log.Fatalf("interval must be positive, got %s", *interval)
+	}
+
+	client := coingeckoapi.New()
+	if *apiKey != "" {
+		client.SetAPIKey(*apiKey, strings.ToLower(*plan))
+	}
+	if *baseURL != "" {
+		client.SetBaseURL(*baseURL)
+	}
+	metrics := newClientMetrics()
+	client.SetRequestObserver(metrics.observe)
+
+	p := &poller{
+		client:   client,
+		coins:    splitList(*coins),
+		vs:       splitList(*vs),
+		interval: *interval,
+	}
+	go p.run(context.Background())
+
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		p.write(w)
+		metrics.write(w)
+	})
+	log.Printf("coingecko-exporter listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, x := range strings.Split(strings.ToLower(s), ",") {
+		if x = strings.TrimSpace(x); x != "" {
+			out = append(out, x)
+		}
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+)
+
+// path segments after these are ids, folded so every coin shares one endpoint label
+var idPrefixes = map[string]bool{
+	"coins":                     true,
+	"exchanges":                 true,
+	"nfts":                      true,
+	"derivatives/exchanges":     true,
+	"companies/public_treasury": true,
+}
+
+// fixed paths under the id prefixes
+var notIDs = map[string]bool{
+	"list":       true,
+	"markets":    true,
+	"categories": true,
+}
+
+type requestKey struct {
+	endpoint string
+	status   string
+}
+
+type latency struct {
+	count int64
+	sum   float64
+}
+
+// client request metrics, fed by the client's request observer
+type clientMetrics struct {
+	mu       sync.Mutex
+	requests map[requestKey]int64
+	errors   map[string]int64
+	latency  map[string]*latency
+}
+
+func newClientMetrics() *clientMetrics {
+	return &clientMetrics{
+		requests: make(map[requestKey]int64),
+		errors:   make(map[string]int64),
+		latency:  make(map[string]*latency),
+	}
+}
+
+func (m *clientMetrics) observe(info coingeckoapi.RequestInfo) {
+	endpoint := info.Product + ":" + endpointLabel(info.Path)
+	status := strconv.Itoa(info.Status)
+	if info.Status == 0 {
+		status = "none"
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestKey{endpoint, status}]++
+	if info.Err != nil {
+		m.errors[endpoint]++
+	}
+	l, ok := m.latency[endpoint]
+	if !ok {
+		l = &latency{}
+		m.latency[endpoint] = l
+	}
+	l.count++
+	l.sum += info.Latency.Seconds()
+}
+
+// ex => coins/bitcoin/ohlc to coins/{id}/ohlc
+func endpointLabel(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(parts); i++ {
+		if idPrefixes[strings.Join(parts[:i], "/")] && !notIDs[parts[i]] {
+			parts[i] = "{id}"
+			break
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func (m *clientMetrics) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].status < keys[j].status
+	})
+	writeHeader(w, "coingecko_client_requests_total", "counter", "upstream calls by endpoint and http status")
+	for _, k := range keys {
+		writeSample(w, "coingecko_client_requests_total", labels("endpoint", k.endpoint, "status", k.status), float64(m.requests[k]))
+	}
+
+	writeHeader(w, "coingecko_client_request_errors_total", "counter", "upstream calls that failed, transport errors and non 200 responses")
+	for _, endpoint := range sortedNames(m.errors) {
+		writeSample(w, "coingecko_client_request_errors_total", labels("endpoint", endpoint), float64(m.errors[endpoint]))
+	}
+
+	writeHeader(w, "coingecko_client_request_duration_seconds", "summary", "upstream call latency")
+	endpoints := make([]string, 0, len(m.latency))
+	for endpoint := range m.latency {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		l := m.latency[endpoint]
+		writeSample(w, "coingecko_client_request_duration_seconds_sum", labels("endpoint", endpoint), l.sum)
+		writeSample(w, "coingecko_client_request_duration_seconds_count", labels("endpoint", endpoint), float64(l.count))
+	}
+}
+
+func sortedNames(m map[string]int64) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// prometheus text exposition format, version 0.0.4
+
+func writeHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func writeSample(w io.Writer, name, labels string, value float64) {
+	fmt.Fprintf(w, "%s%s %s\n", name, labels, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// pairs of label name and value
+func labels(kv ...string) string {
+	if len(kv) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, kv[i], labelEscaper.Replace(kv[i+1])))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
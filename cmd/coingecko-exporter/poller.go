@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+)
+
+// polls simple/price for the tracked coins and keeps the last result
+type poller struct {
+	client   *coingeckoapi.Client
+	coins    []string
+	vs       []string
+	interval time.Duration
+
+	mu       sync.Mutex
+	prices   coingeckoapi.SimplePricesResponse
+	polledAt time.Time
+	polls    int64
+	failures int64
+}
+
+func (p *poller) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *poller) poll(ctx context.Context) {
+	// SimplePricesContext splits long coin lists into calls of 100 ids
+	prices, err := p.client.SimplePricesContext(ctx, p.coins, p.vs, &coingeckoapi.SimplePriceOptions{
+		IncludeMarketCap:     true,
+		Include24hVol:        true,
+		Include24hChange:     true,
+		IncludeLastUpdatedAt: true,
+		Precision:            "full",
+	})
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.polls++
+	if err != nil {
+		p.failures++
+		log.Printf("poll: %v", err)
+		return
+	}
+	// a failed poll keeps the last prices, their age shows in last_updated_age
+	p.prices = prices
+	p.polledAt = time.Now()
+}
+
+func (p *poller) write(w io.Writer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+
+	gauges := []struct {
+		name  string
+		help  string
+		value func(q coingeckoapi.SimplePriceQuote, vs string) (float64, bool)
+	}{
+		{"coingecko_price", "price of the coin in the vs currency", func(q coingeckoapi.SimplePriceQuote, vs string) (float64, bool) {
+			v, ok := q.Price[vs]
+			return v.InexactFloat64(), ok
+		}},
+		{"coingecko_market_cap", "market cap in the vs currency", func(q coingeckoapi.SimplePriceQuote, vs string) (float64, bool) {
+			v, ok := q.MarketCap[vs]
+			return v.InexactFloat64(), ok
+		}},
+		{"coingecko_volume_24h", "24h trading volume in the vs currency", func(q coingeckoapi.SimplePriceQuote, vs string) (float64, bool) {
+			v, ok := q.Vol24h[vs]
+			return v.InexactFloat64(), ok
+		}},
+		{"coingecko_change_24h_percent", "24h price change in percent", func(q coingeckoapi.SimplePriceQuote, vs string) (float64, bool) {
+			v, ok := q.Change24h[vs]
+			return v.InexactFloat64(), ok
+		}},
+		{"coingecko_last_updated_age_seconds", "seconds since coingecko last updated the price", func(q coingeckoapi.SimplePriceQuote, vs string) (float64, bool) {
+			if q.LastUpdatedAt.IsZero() {
+				return 0, false
+			}
+			_, ok := q.Price[vs]
+			return now.Sub(q.LastUpdatedAt).Seconds(), ok
+		}},
+	}
+	for _, g := range gauges {
+		writeHeader(w, g.name, "gauge", g.help)
+		for _, coin := range p.coins {
+			q, ok := p.prices[coin]
+			if !ok {
+				continue
+			}
+			for _, vs := range p.vs {
+				if v, ok := g.value(q, vs); ok {
+					writeSample(w, g.name, labels("coin", coin, "currency", vs), v)
+				}
+			}
+		}
+	}
+
+	writeHeader(w, "coingecko_exporter_polls_total", "counter", "simple/price polls")
+	writeSample(w, "coingecko_exporter_polls_total", "", float64(p.polls))
+	writeHeader(w, "coingecko_exporter_poll_failures_total", "counter", "simple/price polls that failed")
+	writeSample(w, "coingecko_exporter_poll_failures_total", "", float64(p.failures))
+	if !p.polledAt.IsZero() {
+		writeHeader(w, "coingecko_exporter_last_poll_timestamp_seconds", "gauge", "unix time of the last successful poll")
+		writeSample(w, "coingecko_exporter_last_poll_timestamp_seconds", "", float64(p.polledAt.UnixNano())/1e9)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	body      []byte
+	fetchedAt time.Time
+	// a background refresh is running
+	refreshing bool
+}
+
+// upstream 200 responses by path and query, at most max of them
+type cache struct {
+	ttl   time.Duration
+	stale time.Duration
+	max   int
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+func newCache(ttl, stale time.Duration, max int) (*cache, error) {
+	if max < 1 {
+		return nil, errors.New("cache size must be at least 1")
+	}
+	return &cache{
+		ttl:     ttl,
+		stale:   stale,
+		max:     max,
+		entries: make(map[string]*entry),
+	}, nil
+}
+
+const (
+	cacheMiss = iota
+	cacheHit
+	// served but past ttl, the caller should refresh it
+	cacheStale
+)
+
+// body and state of key, refresh is true for the one caller that should revalidate a stale entry
+func (c *cache) get(key string) (body []byte, state int, refresh bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, cacheMiss, false
+	}
+	age := time.Since(e.fetchedAt)
+	switch {
+	case age < c.ttl:
+		return e.body, cacheHit, false
+	case age < c.ttl+c.stale:
+		refresh = !e.refreshing
+		e.refreshing = true
+		return e.body, cacheStale, refresh
+	default:
+		return nil, cacheMiss, false
+	}
+}
+
+// a new key in a full cache first drops the expired entries, then the oldest one
+func (c *cache) set(key string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.max {
+		c.dropExpired()
+		if len(c.entries) >= c.max {
+			c.dropOldest()
+		}
+	}
+	c.entries[key] = &entry{body: body, fetchedAt: time.Now()}
+}
+
+// lets the next stale read retry a failed refresh
+func (c *cache) refreshFailed(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.refreshing = false
+	}
+}
+
+// drops entries too old to be served at all
+func (c *cache) sweep() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dropExpired()
+}
+
+func (c *cache) dropExpired() {
+	for key, e := range c.entries {
+		if time.Since(e.fetchedAt) >= c.ttl+c.stale {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *cache) dropOldest() {
+	var oldest string
+	var at time.Time
+	for key, e := range c.entries {
+		if at.IsZero() || e.fetchedAt.Before(at) {
+			oldest, at = key, e.fetchedAt
+		}
+	}
+	delete(c.entries, oldest)
+}
+
+func (c *cache) size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
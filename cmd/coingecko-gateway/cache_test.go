@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheStates(t *testing.T) {
+	c, err := newCache(time.Minute, time.Minute, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, state, _ := c.get("a"); state != cacheMiss {
+		t.Errorf("empty cache state %d, want miss", state)
+	}
+	c.set("a", []byte("1"))
+	if body, state, _ := c.get("a"); state != cacheHit || string(body) != "1" {
+		t.Errorf("fresh entry = %q state %d, want hit", body, state)
+	}
+
+	c.entries["a"].fetchedAt = time.Now().Add(-90 * time.Second)
+	if _, state, refresh := c.get("a"); state != cacheStale || !refresh {
+		t.Errorf("first stale read state %d refresh %v, want stale and refresh", state, refresh)
+	}
+	if _, state, refresh := c.get("a"); state != cacheStale || refresh {
+		t.Errorf("second stale read state %d refresh %v, want stale without refresh", state, refresh)
+	}
+	c.refreshFailed("a")
+	if _, _, refresh := c.get("a"); !refresh {
+		t.Error("stale read after a failed refresh did not retry")
+	}
+
+	c.entries["a"].fetchedAt = time.Now().Add(-3 * time.Minute)
+	if _, state, _ := c.get("a"); state != cacheMiss {
+		t.Errorf("expired entry state %d, want miss", state)
+	}
+	c.sweep()
+	if c.size() != 0 {
+		t.Errorf("sweep left %d entries", c.size())
+	}
+}
+
+func TestCacheSizeCap(t *testing.T) {
+	if _, err := newCache(time.Minute, time.Minute, 0); err == nil {
+		t.Error("size 0 accepted")
+	}
+	c, err := newCache(time.Minute, time.Minute, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.set("a", []byte("1"))
+	c.set("b", []byte("2"))
+	c.entries["a"].fetchedAt = time.Now().Add(-time.Second)
+
+	// updating a key in a full cache evicts nothing
+	c.set("b", []byte("3"))
+	if c.size() != 2 {
+		t.Fatalf("size %d after an update, want 2", c.size())
+	}
+	c.set("c", []byte("4"))
+	if c.size() != 2 {
+		t.Fatalf("size %d, want the cap of 2", c.size())
+	}
+	if _, state, _ := c.get("a"); state != cacheMiss {
+		t.Error("oldest entry a was kept")
+	}
+
+	// an expired entry goes before the oldest live one
+	c.entries["c"].fetchedAt = time.Now().Add(-3 * time.Minute)
+	c.set("d", []byte("5"))
+	if _, state, _ := c.get("b"); state != cacheHit {
+		t.Error("live entry b was evicted while c had expired")
+	}
+	if _, ok := c.entries["c"]; ok {
+		t.Error("expired entry c was kept")
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+type call struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// concurrent fetches of the same key share one upstream call
+type group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+func newGroup() *group {
+	return &group{
+		calls: make(map[string]*call),
+	}
+}
+
+// fn runs once per key at a time, detached from every caller, so one caller
+// leaving never cancels the fetch for the others. each caller stops waiting when its own ctx is done
+// shared is true when the result came from another caller's fetch
+func (g *group) do(ctx context.Context, key string, fn func() ([]byte, error)) (body []byte, shared bool, err error) {
+	g.mu.Lock()
+	c, shared := g.calls[key]
+	if !shared {
+		c = &call{done: make(chan struct{})}
+		g.calls[key] = c
+		go func() {
+			c.body, c.err = fn()
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(c.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.body, shared, c.err
+	case <-ctx.Done():
+		return nil, shared, ctx.Err()
+	}
+}
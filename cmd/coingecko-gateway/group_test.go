@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupCoalesces(t *testing.T) {
+	g := newGroup()
+	var calls int32
+	release := make(chan struct{})
+	fn := func() ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []byte("body"), nil
+	}
+
+	const n = 5
+	var wg sync.WaitGroup
+	var sharedCount int32
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body, shared, err := g.do(context.Background(), "k", fn)
+			if err != nil || string(body) != "body" {
+				t.Errorf("got %q %v", body, err)
+			}
+			if shared {
+				atomic.AddInt32(&sharedCount, 1)
+			}
+		}()
+	}
+	waitFor(t, func() bool { return atomic.LoadInt32(&calls) == 1 && waiting(g, "k") })
+	// let the other callers join before the call ends
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("fn ran %d times, want 1", calls)
+	}
+	if sharedCount != n-1 {
+		t.Errorf("%d callers shared the result, want %d", sharedCount, n-1)
+	}
+	// the key is free again once the call is done
+	if _, shared, _ := g.do(context.Background(), "k", func() ([]byte, error) { return nil, nil }); shared {
+		t.Error("a call after the first one finished was shared")
+	}
+}
+
+func TestGroupCancelOneWaiter(t *testing.T) {
+	g := newGroup()
+	release := make(chan struct{})
+	fn := func() ([]byte, error) {
+		<-release
+		return []byte("body"), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(ctx, "k", fn)
+		firstErr <- err
+	}()
+	waitFor(t, func() bool { return waiting(g, "k") })
+
+	second := make(chan []byte, 1)
+	go func() {
+		body, _, err := g.do(context.Background(), "k", fn)
+		if err != nil {
+			t.Errorf("second caller: %v", err)
+		}
+		second <- body
+	}()
+
+	// the caller that started the fetch leaves, the fetch keeps going for the other
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller got %v", err)
+	}
+	close(release)
+	select {
+	case body := <-second:
+		if string(body) != "body" {
+			t.Errorf("second caller got %q", body)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second caller never got the result")
+	}
+}
+
+func waiting(g *group, key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, ok := g.calls[key]
+	return ok
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
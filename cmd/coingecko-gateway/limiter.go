@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// token bucket shared by every upstream call
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(perMinute, burst int) (*limiter, error) {
+	if perMinute <= 0 {
+		return nil, errors.New("rate must be at least 1 call per minute")
+	}
+	if burst < 1 {
+		return nil, errors.New("burst must be at least 1")
+	}
+	return &limiter{
+		rate:   float64(perMinute) / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// blocks until a token is free or ctx is done
+func (l *limiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterRejectsBadSettings(t *testing.T) {
+	if _, err := newLimiter(0, 1); err == nil {
+		t.Error("rate 0 accepted")
+	}
+	if _, err := newLimiter(30, 0); err == nil {
+		t.Error("burst 0 accepted")
+	}
+}
+
+func TestLimiterBurstThenWaits(t *testing.T) {
+	// one token a second, two at once
+	l, err := newLimiter(60, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		err := l.wait(ctx)
+		cancel()
+		if err != nil {
+			t.Fatalf("call %d inside the burst: %v", i, err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("call past the burst got %v, want it to wait past the deadline", err)
+	}
+}
+
+func TestLimiterRefills(t *testing.T) {
+	// one token every 10ms
+	l, err := newLimiter(6000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	began := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if took := time.Since(began); took < 25*time.Millisecond {
+		t.Errorf("4 calls at burst 1 took %v, want about 30ms", took)
+	}
+}
@@ -0,0 +1,70 @@
+// coingecko-gateway relays /api/v3/... to coingecko through one shared client,
+// with a shared cache, request coalescing and a global rate limit.
+// point a Client at it with SetBaseURL("http://host:port/api/v3").
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	apiKey := flag.String("api-key", os.Getenv("COINGECKO_API_KEY"), "upstream api key, defaults to $COINGECKO_API_KEY")
+	plan := flag.String("plan", os.Getenv("COINGECKO_PLAN"), "plan of the api key, demo or pro")
+	baseURL := flag.String("base-url", "", "replaces the upstream endpoint")
+	ttl := flag.Duration("ttl", 30*time.Second, "how long a response is served fresh")
+	stale := flag.Duration("stale", 5*time.Minute, "how long past ttl a response is served while revalidating")
+	cacheSize := flag.Int("cache-size", 10000, "max responses kept, the oldest goes first when full")
+	rate := flag.Int("rate", 30, "upstream calls per minute for the whole team")
+	burst := flag.Int("burst", 5, "upstream calls allowed at once before the rate applies")
+	timeout := flag.Duration("timeout", 30*time.Second, "max wait for the rate limit and upstream call")
+	flag.Parse()
+
+	client := coingeckoapi.New()
+	if *apiKey != "" {
+		client.SetAPIKey(*apiKey, strings.ToLower(*plan))
+	}
+	if *baseURL != "" {
+		client.SetBaseURL(*baseURL)
+	}
+	lim, err := newLimiter(*rate, *burst)
+	if err != nil {
+		log.Fatal(err)
+	}
+	c, err := newCache(*ttl, *stale, *cacheSize)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := &server{
+		client:  client,
+		cache:   c,
+		group:   newGroup(),
+		limiter: lim,
+		usage:   newUsage(),
+		timeout: *timeout,
+	}
+	go func() {
+		for range time.Tick(time.Minute) {
+			s.cache.sweep()
+		}
+	}()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		// a request may wait out the whole upstream timeout before it writes
+		WriteTimeout: *timeout + 10*time.Second,
+		IdleTimeout:  2 * time.Minute,
+	}
+	log.Printf("coingecko-gateway listening on %s", *addr)
+	log.Fatal(srv.ListenAndServe())
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+)
+
+const (
+	apiPrefix    = "/api/v3/"
+	callerHeader = "X-Caller"
+	cacheHeader  = "X-Cache"
+)
+
+type server struct {
+	client  *coingeckoapi.Client
+	cache   *cache
+	group   *group
+	limiter *limiter
+	usage   *usage
+	timeout time.Duration
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(apiPrefix, s.handleAPI)
+	mux.HandleFunc("/_gateway/usage", s.handleUsage)
+	mux.HandleFunc("/_gateway/health", s.handleHealth)
+	return mux
+}
+
+func (s *server) handleAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "only GET is relayed", http.StatusMethodNotAllowed)
+		return
+	}
+	caller := callerID(r)
+	s.usage.record(caller, func(c *callerUsage) { c.Requests++ })
+
+	path := strings.TrimPrefix(r.URL.Path, apiPrefix)
+	query := r.URL.Query()
+	// Encode sorts the keys, so the same query in any order shares an entry
+	key := path + "?" + query.Encode()
+
+	body, state, refresh := s.cache.get(key)
+	switch state {
+	case cacheHit:
+		s.usage.record(caller, func(c *callerUsage) { c.CacheHits++ })
+		writeJSON(w, body, "HIT")
+		return
+	case cacheStale:
+		s.usage.record(caller, func(c *callerUsage) { c.StaleHits++ })
+		if refresh {
+			go s.revalidate(caller, key, path, query)
+		}
+		writeJSON(w, body, "STALE")
+		return
+	}
+
+	body, shared, err := s.fetch(r.Context(), caller, key, path, query)
+	if shared {
+		s.usage.record(caller, func(c *callerUsage) { c.Coalesced++ })
+	}
+	if err != nil {
+		s.usage.record(caller, func(c *callerUsage) { c.Errors++ })
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, body, "MISS")
+}
+
+// one upstream call per key at a time, waits for the global rate limit
+// the call runs under its own timeout, ctx only bounds how long this caller waits for it
+func (s *server) fetch(ctx context.Context, caller, key, path string, query map[string][]string) ([]byte, bool, error) {
+	return s.group.do(ctx, key, func() ([]byte, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+		if err := s.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		s.usage.record(caller, func(c *callerUsage) { c.UpstreamCalls++ })
+		body, err := s.client.Raw(ctx, path, query)
+		if err != nil {
+			return nil, err
+		}
+		s.cache.set(key, body)
+		return body, nil
+	})
+}
+
+// refreshes a stale entry after it was served
+func (s *server) revalidate(caller, key, path string, query map[string][]string) {
+	if _, _, err := s.fetch(context.Background(), caller, key, path, query); err != nil {
+		s.cache.refreshFailed(key)
+		log.Printf("revalidate %s: %v", key, err)
+	}
+}
+
+func (s *server) handleUsage(w http.ResponseWriter, r *http.Request) {
+	out := struct {
+		Callers      map[string]callerUsage     `json:"callers"`
+		CacheEntries int                        `json:"cache_entries"`
+		Upstream     coingeckoapi.RequestCounts `json:"upstream_recent"`
+	}{
+		Callers:      s.usage.snapshot(),
+		CacheEntries: s.cache.size(),
+		Upstream:     s.client.RecentRequests(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	health := s.client.HealthCheck(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if !health.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(health)
+}
+
+func writeJSON(w http.ResponseWriter, body []byte, cacheState string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(cacheHeader, cacheState)
+	w.Write(body)
+}
+
+// upstream status errors keep their code and body
+func writeError(w http.ResponseWriter, err error) {
+	var status *coingeckoapi.StatusError
+	if errors.As(err, &status) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status.Code)
+		w.Write(status.Body)
+		return
+	}
+	code := http.StatusBadGateway
+	if errors.Is(err, context.DeadlineExceeded) {
+		code = http.StatusGatewayTimeout
+	}
+	http.Error(w, err.Error(), code)
+}
+
+// X-Caller header, remote ip if missing
+func callerID(r *http.Request) string {
+	if c := r.Header.Get(callerHeader); c != "" {
+		return c
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
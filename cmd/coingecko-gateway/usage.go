@@ -0,0 +1,50 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+type callerUsage struct {
+	Requests      int64     `json:"requests"`
+	CacheHits     int64     `json:"cache_hits"`
+	StaleHits     int64     `json:"stale_hits"`
+	Coalesced     int64     `json:"coalesced"`
+	UpstreamCalls int64     `json:"upstream_calls"`
+	Errors        int64     `json:"errors"`
+	LastSeen      time.Time `json:"last_seen"`
+}
+
+// per caller counters, callers name themselves with the X-Caller header
+type usage struct {
+	mu      sync.Mutex
+	callers map[string]*callerUsage
+}
+
+func newUsage() *usage {
+	return &usage{
+		callers: make(map[string]*callerUsage),
+	}
+}
+
+func (u *usage) record(caller string, fn func(c *callerUsage)) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	c, ok := u.callers[caller]
+	if !ok {
+		c = &callerUsage{}
+		u.callers[caller] = c
+	}
+	c.LastSeen = time.Now()
+	fn(c)
+}
+
+func (u *usage) snapshot() map[string]callerUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	out := make(map[string]callerUsage, len(u.callers))
+	for name, c := range u.callers {
+		out[name] = *c
+	}
+	return out
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/dpong/Coingecko_RESTapi/export"
+	"github.com/shopspring/decimal"
+)
+
+// a single run never outlives the rate table
+const converterRefresh = time.Minute
+
+func runPrice(c *coingeckoapi.Client, out *output, args []string) error {
+	fs := flag.NewFlagSet("price", flag.ExitOnError)
+	vs := fs.String("vs", "usd", "quote currencies, comma-separated")
+	rest, err := parseArgs(fs, args, 1, usagePrice)
+	if err != nil {
+		return err
+	}
+	ids := splitList(rest[0])
+	currencies := splitList(*vs)
+	if len(ids) == 0 || len(currencies) == 0 {
+		return fmt.Errorf("usage: coingecko %s", usagePrice)
+	}
+	prices, err := c.SimplePrices(ids, currencies, &coingeckoapi.SimplePriceOptions{
+		Include24hChange:     true,
+		IncludeLastUpdatedAt: true,
+	})
+	if err != nil {
+		return err
+	}
+	r := &result{
+		raw:     prices,
+		headers: []string{"id", "vs", "price", "change_24h", "last_updated"},
+	}
+	for _, id := range ids {
+		quote, ok := prices[id]
+		if !ok {
+			continue
+		}
+		for _, cur := range currencies {
+			price, ok := quote.Price[cur]
+			if !ok {
+				continue
+			}
+			r.rows = append(r.rows, []string{id, cur, fmtDecimal(price), quote.Change24h[cur].StringFixed(2), fmtTime(quote.LastUpdatedAt)})
+		}
+	}
+	return out.print(r)
+}
+
+func runMarkets(c *coingeckoapi.Client, out *output, args []string) error {
+	fs := flag.NewFlagSet("markets", flag.ExitOnError)
+	vs := fs.String("vs", "usd", "quote currency")
+	perPage := fs.Int("per-page", 50, "rows per page, max 250")
+	page := fs.Int("page", 1, "page number")
+	order := fs.String("order", coingeckoapi.MarketOrderMarketCapDesc, "sort order")
+	category := fs.String("category", "", "category id")
+	if _, err := parseArgs(fs, args, 0, usageMarkets); err != nil {
+		return err
+	}
+	markets, err := c.CoinMarkets(*vs, &coingeckoapi.CoinMarketsOptions{
+		Category: *category,
+		Order:    *order,
+		PerPage:  *perPage,
+		Page:     *page,
+	})
+	if err != nil {
+		return err
+	}
+	r := &result{
+		raw:     markets,
+		headers: []string{"rank", "id", "symbol", "price", "change_24h", "market_cap", "volume"},
+		kind:    export.KindMarket,
+	}
+	for _, m := range markets {
+		r.rows = append(r.rows, []string{
+			fmtRank(m.MarketCapRank), m.ID, m.Symbol, fmtDecimal(m.CurrentPrice),
+			m.PriceChangePercentage24h.StringFixed(2), fmtDecimal(m.MarketCap), fmtDecimal(m.TotalVolume),
+		})
+		r.records = append(r.records, m)
+	}
+	return out.print(r)
+}
+
+func runCoin(c *coingeckoapi.Client, out *output, args []string) error {
+	fs := flag.NewFlagSet("coin", flag.ExitOnError)
+	rest, err := parseArgs(fs, args, 1, usageCoin)
+	if err != nil {
+		return err
+	}
+	coin, err := c.CoinData(rest[0])
+	if err != nil {
+		return err
+	}
+	r := &result{
+		raw:     coin,
+		headers: []string{"field", "value"},
+		rows: [][]string{
+			{"id", coin.ID},
+			{"symbol", coin.Symbol},
+			{"name", coin.Name},
+			{"categories", strings.Join(coin.Categories, ", ")},
+			{"market_cap_rank", fmt.Sprint(coin.MarketCapRank)},
+			{"price_usd", decimal.NewFromFloat(coin.MarketData.CurrentPrice.Usd).String()},
+			{"price_btc", decimal.NewFromFloat(coin.MarketData.CurrentPrice.Btc).String()},
+			{"circulating_supply", decimal.NewFromFloat(coin.MarketData.CirculatingSupply).String()},
+			{"homepage", firstNonEmpty(coin.Links.Homepage)},
+			{"last_updated", fmtTime(coin.LastUpdated)},
+		},
+	}
+	return out.print(r)
+}
+
+func runChart(c *coingeckoapi.Client, out *output, args []string) error {
+	fs := flag.NewFlagSet("chart", flag.ExitOnError)
+	vs := fs.String("vs", "usd", "quote currency")
+	days := fs.String("days", "30", "days back, or max")
+	rest, err := parseArgs(fs, args, 1, usageChart)
+	if err != nil {
+		return err
+	}
+	chart, err := c.MarketChart(rest[0], *vs, *days, "")
+	if err != nil {
+		return err
+	}
+	r := &result{
+		raw:     chart,
+		headers: []string{"time", "price", "market_cap", "volume"},
+	}
+	for i, p := range chart.Prices {
+		row := []string{fmtTime(p.Time), fmtDecimal(p.Value), "", ""}
+		if i < len(chart.MarketCaps) && chart.MarketCaps[i].Time.Equal(p.Time) {
+			row[2] = fmtDecimal(chart.MarketCaps[i].Value)
+		}
+		if i < len(chart.TotalVolumes) && chart.TotalVolumes[i].Time.Equal(p.Time) {
+			row[3] = fmtDecimal(chart.TotalVolumes[i].Value)
+		}
+		r.rows = append(r.rows, row)
+	}
+	return out.print(r)
+}
+
+func runOHLC(c *coingeckoapi.Client, out *output, args []string) error {
+	fs := flag.NewFlagSet("ohlc", flag.ExitOnError)
+	vs := fs.String("vs", "usd", "quote currency")
+	days := fs.String("days", "7", "days back, 1, 7, 14, 30, 90, 180, 365 or max")
+	rest, err := parseArgs(fs, args, 1, usageOHLC)
+	if err != nil {
+		return err
+	}
+	candles, err := c.OHLC(rest[0], *vs, *days)
+	if err != nil {
+		return err
+	}
+	r := &result{
+		raw:     candles,
+		headers: []string{"time", "open", "high", "low", "close"},
+		kind:    export.KindCandle,
+	}
+	for _, k := range candles {
+		r.rows = append(r.rows, []string{fmtTime(k.Time), fmtDecimal(k.Open), fmtDecimal(k.High), fmtDecimal(k.Low), fmtDecimal(k.Close)})
+		r.records = append(r.records, k)
+	}
+	return out.print(r)
+}
+
+func runSearch(c *coingeckoapi.Client, out *output, args []string) error {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	rest, err := parseArgs(fs, args, 1, usageSearch)
+	if err != nil {
+		return err
+	}
+	found, err := c.Search(strings.Join(rest, " "))
+	if err != nil {
+		return err
+	}
+	r := &result{
+		raw:     found,
+		headers: []string{"type", "id", "name", "symbol", "rank"},
+	}
+	for _, x := range found.Coins {
+		r.rows = append(r.rows, []string{"coin", x.ID, x.Name, x.Symbol, strconv.Itoa(x.MarketCapRank)})
+	}
+	for _, x := range found.Exchanges {
+		r.rows = append(r.rows, []string{"exchange", x.ID, x.Name, "", ""})
+	}
+	for _, x := range found.Categories {
+		r.rows = append(r.rows, []string{"category", x.ID, x.Name, "", ""})
+	}
+	for _, x := range found.Nfts {
+		r.rows = append(r.rows, []string{"nft", x.ID, x.Name, x.Symbol, ""})
+	}
+	return out.print(r)
+}
+
+func runTrending(c *coingeckoapi.Client, out *output, args []string) error {
+	fs := flag.NewFlagSet("trending", flag.ExitOnError)
+	if _, err := parseArgs(fs, args, 0, usageTrending); err != nil {
+		return err
+	}
+	trending, err := c.Trending()
+	if err != nil {
+		return err
+	}
+	r := &result{
+		raw:     trending,
+		headers: []string{"type", "id", "name", "symbol", "score", "price_btc"},
+	}
+	for _, x := range trending.Coins {
+		r.rows = append(r.rows, []string{"coin", x.Item.ID, x.Item.Name, x.Item.Symbol, strconv.Itoa(x.Item.Score), fmtDecimal(x.Item.PriceBtc)})
+	}
+	for _, x := range trending.Nfts {
+		r.rows = append(r.rows, []string{"nft", x.ID, x.Name, x.Symbol, "", ""})
+	}
+	for _, x := range trending.Categories {
+		r.rows = append(r.rows, []string{"category", x.Slug, x.Name, "", "", ""})
+	}
+	return out.print(r)
+}
+
+func runList(c *coingeckoapi.Client, out *output, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	platform := fs.Bool("platform", false, "include platform contracts")
+	if _, err := parseArgs(fs, args, 0, usageList); err != nil {
+		return err
+	}
+	coins, err := c.CoinList(*platform)
+	if err != nil {
+		return err
+	}
+	r := &result{
+		raw:     coins,
+		headers: []string{"id", "symbol", "name"},
+		kind:    export.KindCoinList,
+	}
+	for _, x := range coins {
+		r.rows = append(r.rows, []string{x.ID, x.Symbol, x.Name})
+		r.records = append(r.records, x)
+	}
+	return out.print(r)
+}
+
+// exchange_rates units first, anything else through CrossRate
+func runConvert(c *coingeckoapi.Client, out *output, args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	rest, err := parseArgs(fs, args, 3, usageConvert)
+	if err != nil {
+		return err
+	}
+	amount, err := decimal.NewFromString(rest[0])
+	if err != nil {
+		return fmt.Errorf("bad amount: %w", err)
+	}
+	from, to := strings.ToLower(rest[1]), strings.ToLower(rest[2])
+
+	type conversion struct {
+		Amount decimal.Decimal `json:"amount"`
+		From   string          `json:"from"`
+		To     string          `json:"to"`
+		Result decimal.Decimal `json:"result"`
+		Path   string          `json:"path"`
+	}
+	res := conversion{Amount: amount, From: from, To: to}
+	conv, err := coingeckoapi.NewConverter(c, converterRefresh)
+	if err != nil {
+		return err
+	}
+	rates, err := conv.Rates()
+	if err != nil {
+		return err
+	}
+	_, okFrom := rates[from]
+	_, okTo := rates[to]
+	if okFrom && okTo {
+		res.Result, err = conv.Convert(amount, from, to)
+		if err != nil {
+			return err
+		}
+		res.Path = "exchange_rates via btc"
+	} else {
+		cross, err := c.CrossRate(from, to)
+		if err != nil {
+			return err
+		}
+		res.Result = amount.Mul(cross.Rate)
+		res.Path = cross.Path
+	}
+	return out.print(&result{
+		raw:     res,
+		headers: []string{"amount", "from", "to", "result", "path"},
+		rows:    [][]string{{fmtDecimal(res.Amount), res.From, res.To, fmtDecimal(res.Result), res.Path}},
+	})
+}
+
+func firstNonEmpty(list []string) string {
+	for _, s := range list {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+// coingecko queries the coingecko api from the terminal
+//
+//	coingecko [-api-key key] [-plan demo|pro] [-base-url url] [-o table|json|csv] <command> [flags] [args]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+)
+
+type command struct {
+	usage string
+	run   func(c *coingeckoapi.Client, out *output, args []string) error
+}
+
+// command usages, kept apart from commands so the run funcs can print them
+const (
+	usagePrice    = "price [-vs usd,eur] <id,id...>"
+	usageMarkets  = "markets [-vs usd] [-per-page 50] [-page 1] [-order market_cap_desc] [-category id]"
+	usageCoin     = "coin <id>"
+	usageChart    = "chart [-vs usd] [-days 30] <id>"
+	usageOHLC     = "ohlc [-vs usd] [-days 7] <id>"
+	usageSearch   = "search <query>"
+	usageTrending = "trending"
+	usageList     = "list [-platform]"
+	usageConvert  = "convert <amount> <from> <to>"
+)
+
+var commands = map[string]command{
+	"price":    {usagePrice, runPrice},
+	"markets":  {usageMarkets, runMarkets},
+	"coin":     {usageCoin, runCoin},
+	"chart":    {usageChart, runChart},
+	"ohlc":     {usageOHLC, runOHLC},
+	"search":   {usageSearch, runSearch},
+	"trending": {usageTrending, runTrending},
+	"list":     {usageList, runList},
+	"convert":  {usageConvert, runConvert},
+}
+
+func main() {
+	apiKey := flag.String("api-key", os.Getenv("COINGECKO_API_KEY"), "api key, defaults to $COINGECKO_API_KEY")
+	plan := flag.String("plan", os.Getenv("COINGECKO_PLAN"), "plan of the api key, demo or pro")
+	baseURL := flag.String("base-url", "", "replaces the api endpoint, ex => a gateway")
+	format := flag.String("o", formatTable, "output format, table, json or csv")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+	out, err := newOutput(os.Stdout, *format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	client := coingeckoapi.New()
+	if *apiKey != "" {
+		client.SetAPIKey(*apiKey, strings.ToLower(*plan))
+	}
+	if *baseURL != "" {
+		client.SetBaseURL(*baseURL)
+	}
+	if err := cmd.run(client, out, flag.Args()[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: coingecko [flags] <command> [command flags] [args]")
+	fmt.Fprintln(os.Stderr, "\nflags:")
+	flag.PrintDefaults()
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
+	}
+}
+
+// parses command flags, errors when fewer than n args remain
+func parseArgs(fs *flag.FlagSet, args []string, n int, usage string) ([]string, error) {
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: coingecko %s\n", usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() < n {
+		return nil, fmt.Errorf("usage: coingecko %s", usage)
+	}
+	return fs.Args(), nil
+}
+
+// comma-separated ids or currencies, trimmed and lowercased, empty ones dropped
+func splitList(s string) []string {
+	var out []string
+	for _, x := range strings.Split(strings.ToLower(s), ",") {
+		if x = strings.TrimSpace(x); x != "" {
+			out = append(out, x)
+		}
+	}
+	return out
+}
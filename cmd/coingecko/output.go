@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dpong/Coingecko_RESTapi/export"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+type output struct {
+	w      io.Writer
+	format string
+}
+
+func newOutput(w io.Writer, format string) (*output, error) {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+	default:
+		return nil, fmt.Errorf("unknown output format: %s", format)
+	}
+	return &output{w: w, format: format}, nil
+}
+
+// what a command prints, raw is the typed library result used for json
+type result struct {
+	raw     interface{}
+	headers []string
+	rows    [][]string
+	// export kind and records, csv goes through the export package when set
+	kind    string
+	records []interface{}
+}
+
+func (o *output) print(r *result) error {
+	switch o.format {
+	case formatJSON:
+		enc := json.NewEncoder(o.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r.raw)
+	case formatCSV:
+		if r.kind != "" {
+			w, err := export.NewCSV(o.w, r.kind, export.Options{})
+			if err != nil {
+				return err
+			}
+			for _, rec := range r.records {
+				if err := w.Write(rec); err != nil {
+					return err
+				}
+			}
+			return w.Flush()
+		}
+		w := csv.NewWriter(o.w)
+		if err := w.Write(r.headers); err != nil {
+			return err
+		}
+		if err := w.WriteAll(r.rows); err != nil {
+			return err
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		tw := tabwriter.NewWriter(o.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(r.headers, "\t")))
+		for _, row := range r.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+func fmtTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func fmtDecimal(d decimal.Decimal) string {
+	return d.String()
+}
+
+// blank when upstream sent null
+func fmtRank(rank *int) string {
+	if rank == nil {
+		return ""
+	}
+	return strconv.Itoa(*rank)
+}
@@ -1,22 +1,31 @@
 package coingeckoapi
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 type CoinListResponse struct {
 	ID     string `json:"id"`
 	Symbol string `json:"symbol"`
 	Name   string `json:"name"`
+	// platform id to contract address, only with include_platform
+	Platforms map[string]string `json:"platforms,omitempty"`
 }
 
 // opt = including platform info inside or not
 func (b *Client) CoinList(platform bool) ([]CoinListResponse, error) {
+	return b.CoinListContext(context.Background(), platform)
+}
+
+func (b *Client) CoinListContext(ctx context.Context, platform bool) ([]CoinListResponse, error) {
 	type opt struct {
 		Platform bool `url:"include_platform"`
 	}
 	input := opt{
 		Platform: platform,
 	}
-	res, err := b.do("spot", http.MethodGet, "coins/list", input, false, false)
+	res, err := b.doContext(ctx, "spot", http.MethodGet, "coins/list", input, false, false)
 	if err != nil {
 		return nil, err
 	}
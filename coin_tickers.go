@@ -0,0 +1,42 @@
+package coingeckoapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type CoinTickersResponse struct {
+	Name    string   `json:"name"`
+	Tickers []Ticker `json:"tickers"`
+}
+
+type CoinTickersOptions struct {
+	// comma-separated if filtering more than 1
+	ExchangeIDs         string `url:"exchange_ids,omitempty"`
+	IncludeExchangeLogo bool   `url:"include_exchange_logo,omitempty"`
+	Page                int    `url:"page,omitempty"`
+	// order ex => TickerOrderTrustScoreDesc
+	Order string `url:"order,omitempty"`
+	// include 2% orderbook depth, cost_to_move_up_usd and cost_to_move_down_usd
+	Depth bool `url:"depth,omitempty"`
+}
+
+// tickers of one coin across exchanges, 100 per page, opt can be nil
+func (b *Client) CoinTickers(coinID string, opt *CoinTickersOptions) (*CoinTickersResponse, error) {
+	return b.CoinTickersContext(context.Background(), coinID, opt)
+}
+
+func (b *Client) CoinTickersContext(ctx context.Context, coinID string, opt *CoinTickersOptions) (*CoinTickersResponse, error) {
+	url := fmt.Sprintf("coins/%s/tickers", coinID)
+	res, err := b.doContext(ctx, "spot", http.MethodGet, url, opt, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := CoinTickersResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
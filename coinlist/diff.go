@@ -0,0 +1,100 @@
+package coinlist
+
+import (
+	"sort"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+)
+
+// symbol or name change of one id
+type Rename struct {
+	ID  string
+	Old coingeckoapi.CoinListResponse
+	New coingeckoapi.CoinListResponse
+}
+
+// contract change of one id on one platform
+// OldAddress is empty when the coin was added to the platform, NewAddress when removed from it
+type PlatformChange struct {
+	ID         string
+	Platform   string
+	OldAddress string
+	NewAddress string
+}
+
+type Changes struct {
+	Added     []coingeckoapi.CoinListResponse
+	Removed   []coingeckoapi.CoinListResponse
+	Renamed   []Rename
+	Platforms []PlatformChange
+}
+
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Renamed) == 0 && len(c.Platforms) == 0
+}
+
+// compares two coins/list results by id, every list of the result is sorted by id
+// empty contract addresses count as no contract, platform changes are only
+// meaningful when both lists were fetched with include_platform
+func Diff(old, new []coingeckoapi.CoinListResponse) Changes {
+	oldByID := byID(old)
+	newByID := byID(new)
+	var out Changes
+	for _, id := range sortedIDs(newByID) {
+		n := newByID[id]
+		o, ok := oldByID[id]
+		if !ok {
+			out.Added = append(out.Added, n)
+			continue
+		}
+		if o.Symbol != n.Symbol || o.Name != n.Name {
+			out.Renamed = append(out.Renamed, Rename{ID: id, Old: o, New: n})
+		}
+		out.Platforms = append(out.Platforms, diffPlatforms(id, o.Platforms, n.Platforms)...)
+	}
+	for _, id := range sortedIDs(oldByID) {
+		if _, ok := newByID[id]; !ok {
+			out.Removed = append(out.Removed, oldByID[id])
+		}
+	}
+	return out
+}
+
+func diffPlatforms(id string, old, new map[string]string) []PlatformChange {
+	platforms := map[string]struct{}{}
+	for p := range old {
+		platforms[p] = struct{}{}
+	}
+	for p := range new {
+		platforms[p] = struct{}{}
+	}
+	names := make([]string, 0, len(platforms))
+	for p := range platforms {
+		names = append(names, p)
+	}
+	sort.Strings(names)
+	var out []PlatformChange
+	for _, p := range names {
+		if old[p] != new[p] {
+			out = append(out, PlatformChange{ID: id, Platform: p, OldAddress: old[p], NewAddress: new[p]})
+		}
+	}
+	return out
+}
+
+func byID(list []coingeckoapi.CoinListResponse) map[string]coingeckoapi.CoinListResponse {
+	out := make(map[string]coingeckoapi.CoinListResponse, len(list))
+	for _, c := range list {
+		out[c.ID] = c
+	}
+	return out
+}
+
+func sortedIDs(m map[string]coingeckoapi.CoinListResponse) []string {
+	out := make([]string, 0, len(m))
+	for id := range m {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,138 @@
+package coinlist
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+)
+
+// event kinds
+const (
+	KindListed          = "listed"
+	KindDelisted        = "delisted"
+	KindRenamed         = "renamed"
+	KindPlatformChanged = "platform_changed"
+)
+
+// one change found by a refresh
+type Event struct {
+	Kind string
+	// the coin as listed now, as it was last listed for KindDelisted, only ID for KindPlatformChanged
+	Coin coingeckoapi.CoinListResponse
+	// KindRenamed only
+	Old coingeckoapi.CoinListResponse
+	// KindPlatformChanged only
+	Platform PlatformChange
+	Time     time.Time
+}
+
+// events in the order listed, delisted, renamed, platform changed
+func (c Changes) Events(at time.Time) []Event {
+	var out []Event
+	for _, coin := range c.Added {
+		out = append(out, Event{Kind: KindListed, Coin: coin, Time: at})
+	}
+	for _, coin := range c.Removed {
+		out = append(out, Event{Kind: KindDelisted, Coin: coin, Time: at})
+	}
+	for _, r := range c.Renamed {
+		out = append(out, Event{Kind: KindRenamed, Coin: r.New, Old: r.Old, Time: at})
+	}
+	for _, p := range c.Platforms {
+		out = append(out, Event{Kind: KindPlatformChanged, Coin: coingeckoapi.CoinListResponse{ID: p.ID}, Platform: p, Time: at})
+	}
+	return out
+}
+
+// fetches coins/list with platforms on a schedule, diffs it against the snapshot
+// at path, emits the changes and saves the new list as the snapshot
+type Refresher struct {
+	client   *coingeckoapi.Client
+	path     string
+	interval time.Duration
+	events   chan Event
+	errs     chan error
+
+	last *Snapshot
+}
+
+// call Run to start refreshing
+func NewRefresher(client *coingeckoapi.Client, path string, interval time.Duration) (*Refresher, error) {
+	if interval <= 0 {
+		return nil, errors.New("refresher interval must be positive")
+	}
+	return &Refresher{
+		client:   client,
+		path:     path,
+		interval: interval,
+		events:   make(chan Event, 256),
+		errs:     make(chan error, 1),
+	}, nil
+}
+
+// closed when Run returns
+func (r *Refresher) Events() <-chan Event {
+	return r.events
+}
+
+// failed coins/list fetches and snapshot saves, the first unread one is kept and later ones are lost
+func (r *Refresher) Errors() <-chan error {
+	return r.errs
+}
+
+// refreshes every interval until ctx is done, then closes Events
+// without a snapshot on disk the first refresh only saves one, so a fresh start emits no listings
+func (r *Refresher) Run(ctx context.Context) error {
+	defer close(r.events)
+	snap, err := Load(r.path)
+	switch {
+	case err == nil:
+		r.last = snap
+	case os.IsNotExist(err):
+		// pass
+	default:
+		return err
+	}
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.refresh(ctx); err != nil {
+			select {
+			case r.errs <- err:
+			default:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// one fetch, diff and save, the snapshot only moves once every event is delivered
+// so a failed save repeats the events on the next refresh rather than losing them
+func (r *Refresher) refresh(ctx context.Context) error {
+	coins, err := r.client.CoinListContext(ctx, true)
+	if err != nil {
+		return err
+	}
+	snap := NewSnapshot(coins, time.Now())
+	if r.last != nil {
+		for _, e := range Diff(r.last.Coins, snap.Coins).Events(snap.FetchedAt) {
+			select {
+			case r.events <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	if err := snap.Save(r.path); err != nil {
+		return err
+	}
+	r.last = snap
+	return nil
+}
@@ -0,0 +1,63 @@
+package coinlist
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+)
+
+// the coins/list registry at one point in time
+type Snapshot struct {
+	FetchedAt time.Time                       `json:"fetched_at"`
+	Coins     []coingeckoapi.CoinListResponse `json:"coins"`
+}
+
+// coins sorted by id, so saved files diff cleanly
+func NewSnapshot(coins []coingeckoapi.CoinListResponse, at time.Time) *Snapshot {
+	sorted := make([]coingeckoapi.CoinListResponse, len(coins))
+	copy(sorted, coins)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	return &Snapshot{FetchedAt: at, Coins: sorted}
+}
+
+// missing file returns an error satisfying os.IsNotExist
+func Load(path string) (*Snapshot, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	snap := &Snapshot{}
+	if err := json.Unmarshal(raw, snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// written to a temp file then renamed, a crash never leaves half a snapshot
+func (s *Snapshot) Save(path string) error {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
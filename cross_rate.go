@@ -0,0 +1,198 @@
+package coingeckoapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// the vs list changes rarely
+const supportedVsTTL = 24 * time.Hour
+
+// coin used to cross two vs currencies
+const crossCoin = "bitcoin"
+
+// paths tried for two coins, in order
+var crossVia = []string{"usd", "btc"}
+
+// one simple/price quote used by CrossRate
+type CrossRateLeg struct {
+	CoinID    string
+	Vs        string
+	Price     decimal.Decimal
+	UpdatedAt time.Time
+	// age of the quote when it was fetched
+	Staleness time.Duration
+}
+
+type CrossRateResult struct {
+	Base  string
+	Quote string
+	// how many quote for 1 base
+	Rate decimal.Decimal
+	// ex => direct, inverse, via usd, inverse via btc, via bitcoin
+	Path string
+	Legs []CrossRateLeg
+}
+
+type supportedVsCache struct {
+	mu        sync.Mutex
+	set       map[string]bool
+	updatedAt time.Time
+}
+
+// all the quote currencies simple/price accepts
+func (b *Client) SupportedVsCurrencies() ([]string, error) {
+	return b.SupportedVsCurrenciesContext(context.Background())
+}
+
+func (b *Client) SupportedVsCurrenciesContext(ctx context.Context) ([]string, error) {
+	res, err := b.doContext(ctx, "spot", http.MethodGet, "simple/supported_vs_currencies", nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b *Client) isVsCurrency(ctx context.Context, s string) (bool, error) {
+	c := &b.supportedVs
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set == nil || time.Since(c.updatedAt) >= supportedVsTTL {
+		list, err := b.SupportedVsCurrenciesContext(ctx)
+		if err != nil {
+			return false, err
+		}
+		c.set = make(map[string]bool, len(list))
+		for _, vs := range list {
+			c.set[vs] = true
+		}
+		c.updatedAt = time.Now()
+	}
+	return c.set[s], nil
+}
+
+// base and quote are coin ids or vs currencies, ex => solana in avalanche-2, usdc in eur
+// the rate comes from one simple/price call, directly if coingecko quotes the pair, else crossed through usd or btc
+func (b *Client) CrossRate(base, quote string) (*CrossRateResult, error) {
+	return b.CrossRateContext(context.Background(), base, quote)
+}
+
+func (b *Client) CrossRateContext(ctx context.Context, base, quote string) (*CrossRateResult, error) {
+	base, quote = strings.ToLower(base), strings.ToLower(quote)
+	out := CrossRateResult{Base: base, Quote: quote}
+	if base == quote {
+		out.Rate, out.Path = decimal.NewFromInt(1), "direct"
+		return &out, nil
+	}
+	baseVs, err := b.isVsCurrency(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	quoteVs, err := b.isVsCurrency(ctx, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	idSet := map[string]struct{}{}
+	vsSet := map[string]struct{}{}
+	for _, vs := range crossVia {
+		vsSet[vs] = struct{}{}
+	}
+	for _, s := range []struct {
+		name string
+		vs   bool
+	}{{base, baseVs}, {quote, quoteVs}} {
+		if s.vs {
+			vsSet[s.name] = struct{}{}
+		} else {
+			idSet[s.name] = struct{}{}
+		}
+	}
+	// any vs side may need the cross coin when the coin has no direct quote
+	if baseVs || quoteVs {
+		idSet[crossCoin] = struct{}{}
+	}
+	prices, err := b.SimplePricesContext(ctx, sortedKeys(idSet), sortedKeys(vsSet), &SimplePriceOptions{IncludeLastUpdatedAt: true, Precision: "full"})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	leg := func(coin, vs string) (CrossRateLeg, bool) {
+		q, ok := prices[coin]
+		if !ok {
+			return CrossRateLeg{}, false
+		}
+		price, ok := q.Price[vs]
+		if !ok || price.IsZero() {
+			return CrossRateLeg{}, false
+		}
+		out := CrossRateLeg{CoinID: coin, Vs: vs, Price: price, UpdatedAt: q.LastUpdatedAt}
+		if !q.LastUpdatedAt.IsZero() {
+			out.Staleness = now.Sub(q.LastUpdatedAt)
+		}
+		return out, true
+	}
+	// coin in vs, else coin in usd or btc and the cross coin between that and vs
+	coinIn := func(coin, vs string) (decimal.Decimal, string, []CrossRateLeg, bool) {
+		if l, ok := leg(coin, vs); ok {
+			return l.Price, "", []CrossRateLeg{l}, true
+		}
+		for _, via := range crossVia {
+			l1, ok1 := leg(coin, via)
+			l2, ok2 := leg(crossCoin, via)
+			l3, ok3 := leg(crossCoin, vs)
+			if ok1 && ok2 && ok3 {
+				return l1.Price.Div(l2.Price).Mul(l3.Price), "via " + via, []CrossRateLeg{l1, l2, l3}, true
+			}
+		}
+		return decimal.Zero, "", nil, false
+	}
+
+	switch {
+	case !baseVs && quoteVs:
+		if price, via, legs, ok := coinIn(base, quote); ok {
+			out.Rate, out.Path, out.Legs = price, "direct", legs
+			if via != "" {
+				out.Path = via
+			}
+			return &out, nil
+		}
+	case baseVs && !quoteVs:
+		if price, via, legs, ok := coinIn(quote, base); ok {
+			out.Rate, out.Path, out.Legs = decimal.NewFromInt(1).Div(price), "inverse", legs
+			if via != "" {
+				out.Path = "inverse " + via
+			}
+			return &out, nil
+		}
+	case baseVs && quoteVs:
+		l1, ok1 := leg(crossCoin, base)
+		l2, ok2 := leg(crossCoin, quote)
+		if ok1 && ok2 {
+			out.Rate, out.Path, out.Legs = l2.Price.Div(l1.Price), "via "+crossCoin, []CrossRateLeg{l1, l2}
+			return &out, nil
+		}
+	default:
+		for _, via := range crossVia {
+			l1, ok1 := leg(base, via)
+			l2, ok2 := leg(quote, via)
+			if ok1 && ok2 {
+				out.Rate, out.Path, out.Legs = l1.Price.Div(l2.Price), "via "+via, []CrossRateLeg{l1, l2}
+				return &out, nil
+			}
+		}
+	}
+	return nil, errors.New(fmt.Sprintf("no price path from %s to %s", base, quote))
+}
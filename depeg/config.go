@@ -0,0 +1,82 @@
+package depeg
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// event levels, LevelNone is a recovery
+const (
+	LevelNone     = ""
+	LevelWatch    = "watch"
+	LevelWarn     = "warn"
+	LevelCritical = "critical"
+)
+
+// lowest first
+var levels = []string{LevelWatch, LevelWarn, LevelCritical}
+
+type Config struct {
+	// simple/price poll interval
+	Interval time.Duration
+	// tickers cost one call per coin, zero fetches them on every poll
+	// a median older than twice this, or twice Interval if larger, stops counting
+	TickerInterval time.Duration
+	// absolute deviation from the peg in percent, ex => 0.5 for 0.5%
+	Watch    decimal.Decimal
+	Warn     decimal.Decimal
+	Critical decimal.Decimal
+	// how long a deviation must hold before its level is raised, recoveries wait as long
+	Sustain time.Duration
+	// how far back History reaches
+	Keep time.Duration
+}
+
+var DefaultConfig = Config{
+	Interval:       time.Minute,
+	TickerInterval: 5 * time.Minute,
+	Watch:          decimal.NewFromFloat(0.5),
+	Warn:           decimal.NewFromInt(1),
+	Critical:       decimal.NewFromInt(3),
+	Sustain:        5 * time.Minute,
+	Keep:           24 * time.Hour,
+}
+
+func (c Config) validate() error {
+	if c.Interval <= 0 {
+		return errors.New("depeg interval must be positive")
+	}
+	if c.TickerInterval < 0 || c.Sustain < 0 {
+		return errors.New("depeg ticker interval and sustain must not be negative")
+	}
+	if c.Keep <= 0 {
+		return errors.New("depeg keep must be positive")
+	}
+	if !c.Watch.IsPositive() || c.Warn.LessThan(c.Watch) || c.Critical.LessThan(c.Warn) {
+		return errors.New("depeg thresholds must be positive and watch <= warn <= critical")
+	}
+	return nil
+}
+
+// how long a ticker median stays usable
+func (c Config) tickerTTL() time.Duration {
+	ttl := c.TickerInterval
+	if c.Interval > ttl {
+		ttl = c.Interval
+	}
+	return 2 * ttl
+}
+
+func (c Config) threshold(level string) decimal.Decimal {
+	switch level {
+	case LevelWatch:
+		return c.Watch
+	case LevelWarn:
+		return c.Warn
+	case LevelCritical:
+		return c.Critical
+	}
+	return decimal.Zero
+}
@@ -0,0 +1,77 @@
+package depeg
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// one poll of one stablecoin, prices are in the peg currency
+type Sample struct {
+	Time  time.Time       `json:"time"`
+	Price decimal.Decimal `json:"price"`
+	// median converted_last across exchanges, zero when no ticker was usable
+	TickerMedian decimal.Decimal `json:"ticker_median"`
+	Tickers      int             `json:"tickers"`
+	// signed percent off the peg, the larger of the price and ticker median deviations
+	Deviation decimal.Decimal `json:"deviation"`
+}
+
+// samples of one coin, oldest first
+type history struct {
+	samples []Sample
+}
+
+func (h *history) add(s Sample, keep time.Duration) {
+	h.samples = append(h.samples, s)
+	cut := 0
+	for cut < len(h.samples) && s.Time.Sub(h.samples[cut].Time) > keep {
+		cut++
+	}
+	h.samples = h.samples[cut:]
+}
+
+func (h *history) since(t time.Time) []Sample {
+	var out []Sample
+	for _, s := range h.samples {
+		if !s.Time.Before(t) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// deviation range over some samples
+type Summary struct {
+	From    time.Time       `json:"from"`
+	To      time.Time       `json:"to"`
+	Samples int             `json:"samples"`
+	Min     decimal.Decimal `json:"min"`
+	Max     decimal.Decimal `json:"max"`
+	Mean    decimal.Decimal `json:"mean"`
+}
+
+func summarize(samples []Sample) Summary {
+	if len(samples) == 0 {
+		return Summary{}
+	}
+	out := Summary{
+		From:    samples[0].Time,
+		To:      samples[len(samples)-1].Time,
+		Samples: len(samples),
+		Min:     samples[0].Deviation,
+		Max:     samples[0].Deviation,
+	}
+	sum := decimal.Zero
+	for _, s := range samples {
+		if s.Deviation.LessThan(out.Min) {
+			out.Min = s.Deviation
+		}
+		if s.Deviation.GreaterThan(out.Max) {
+			out.Max = s.Deviation
+		}
+		sum = sum.Add(s.Deviation)
+	}
+	out.Mean = sum.Div(decimal.NewFromInt(int64(len(samples))))
+	return out
+}
@@ -0,0 +1,393 @@
+package depeg
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+// converted_last is in these units, anything else goes through usd
+const tickerBaseVs = "usd"
+
+// ID is from coins/list endpoint, Peg ex => usd, eur
+type Stablecoin struct {
+	ID  string `json:"id"`
+	Peg string `json:"peg"`
+}
+
+// a level change of one stablecoin
+type Event struct {
+	Coin     Stablecoin `json:"coin"`
+	Level    string     `json:"level"`
+	Previous string     `json:"previous"`
+	// the sample that completed the sustain period
+	Sample Sample `json:"sample"`
+	// when the deviation first crossed into Level, or left Previous for a downgrade
+	Since time.Time `json:"since"`
+}
+
+func (e Event) String() string {
+	if e.Level == LevelNone {
+		return fmt.Sprintf("%s recovered from %s, %s%% off %s", e.Coin.ID, e.Previous, e.Sample.Deviation.StringFixed(3), e.Coin.Peg)
+	}
+	return fmt.Sprintf("%s %s, %s%% off %s since %s", e.Coin.ID, e.Level, e.Sample.Deviation.StringFixed(3), e.Coin.Peg, e.Since.UTC().Format(time.RFC3339))
+}
+
+type coinState struct {
+	level string
+	// when the deviation reached each level, missing when below it
+	reached map[string]time.Time
+	// when the deviation fell below the current level, zero while at or above it
+	calm time.Time
+
+	tickerMedian decimal.Decimal
+	tickers      int
+	// last successful ticker fetch, the median expires from here
+	tickersAt time.Time
+	// last ticker fetch, failed or not, the next one is due from here
+	tickersTriedAt time.Time
+
+	history history
+}
+
+// polls simple/price and coin tickers, grades each stablecoin's deviation from its peg
+type Monitor struct {
+	client *coingeckoapi.Client
+	cfg    Config
+	events chan Event
+	errs   chan error
+
+	mu    sync.Mutex
+	coins map[string]Stablecoin
+	state map[string]*coinState
+}
+
+// call Run to start polling
+func NewMonitor(client *coingeckoapi.Client, cfg Config, coins ...Stablecoin) (*Monitor, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	m := &Monitor{
+		client: client,
+		cfg:    cfg,
+		events: make(chan Event, 64),
+		errs:   make(chan error, 1),
+		coins:  make(map[string]Stablecoin),
+		state:  make(map[string]*coinState),
+	}
+	m.Add(coins...)
+	return m, nil
+}
+
+// closed when Run returns
+func (m *Monitor) Events() <-chan Event {
+	return m.events
+}
+
+// failed polls and ticker fetches, while one waits unread the newer ones are discarded
+func (m *Monitor) Errors() <-chan error {
+	return m.errs
+}
+
+// replaces the peg of a coin already monitored, its history is kept
+func (m *Monitor) Add(coins ...Stablecoin) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range coins {
+		c.ID, c.Peg = strings.ToLower(c.ID), strings.ToLower(c.Peg)
+		m.coins[c.ID] = c
+		if _, ok := m.state[c.ID]; !ok {
+			m.state[c.ID] = &coinState{reached: make(map[string]time.Time)}
+		}
+	}
+}
+
+func (m *Monitor) Remove(ids ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		id = strings.ToLower(id)
+		delete(m.coins, id)
+		delete(m.state, id)
+	}
+}
+
+// current level of a coin, LevelNone when unknown
+func (m *Monitor) Level(id string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.state[strings.ToLower(id)]; ok {
+		return s.level
+	}
+	return LevelNone
+}
+
+// samples of a coin at or after since, oldest first
+func (m *Monitor) History(id string, since time.Time) []Sample {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.state[strings.ToLower(id)]
+	if !ok {
+		return nil
+	}
+	return s.history.since(since)
+}
+
+// deviation range of a coin at or after since
+func (m *Monitor) Summary(id string, since time.Time) Summary {
+	return summarize(m.History(id, since))
+}
+
+// polls every interval until ctx is done, then closes Events
+func (m *Monitor) Run(ctx context.Context) error {
+	defer close(m.events)
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if err := m.Poll(ctx); err != nil {
+			m.report(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// one round of fetching prices and due tickers, grading and emitting level changes
+func (m *Monitor) Poll(ctx context.Context) error {
+	now := time.Now()
+	coins, due := m.snapshot(now)
+	if len(coins) == 0 {
+		return nil
+	}
+	idSet := map[string]struct{}{}
+	vsSet := map[string]struct{}{tickerBaseVs: {}}
+	for _, c := range coins {
+		idSet[c.ID] = struct{}{}
+		vsSet[c.Peg] = struct{}{}
+	}
+	// any number of coins, SimplePricesContext splits the ids into calls of 100
+	prices, err := m.client.SimplePricesContext(ctx, keys(idSet), keys(vsSet), &coingeckoapi.SimplePriceOptions{Precision: "full"})
+	if err != nil {
+		return err
+	}
+
+	medians := make(map[string]tickerMedian, len(due))
+	for _, c := range due {
+		res, err := m.client.CoinTickersContext(ctx, c.ID, &coingeckoapi.CoinTickersOptions{Order: coingeckoapi.TickerOrderTrustScoreDesc})
+		if err != nil {
+			m.report(fmt.Errorf("%s tickers: %w", c.ID, err))
+			continue
+		}
+		medians[c.ID] = medianTicker(res.Tickers, c.Peg, prices[c.ID])
+	}
+	m.markTickersTried(due, now)
+
+	for _, e := range m.evaluate(coins, prices, medians, now) {
+		select {
+		case m.events <- e:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// monitored coins and the ones whose tickers are due
+func (m *Monitor) snapshot(now time.Time) ([]Stablecoin, []Stablecoin) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var coins, due []Stablecoin
+	for _, id := range sortedIDs(m.coins) {
+		c := m.coins[id]
+		coins = append(coins, c)
+		if now.Sub(m.state[id].tickersTriedAt) >= m.cfg.TickerInterval {
+			due = append(due, c)
+		}
+	}
+	return coins, due
+}
+
+// a failed fetch waits a full TickerInterval too, rather than retrying every poll
+func (m *Monitor) markTickersTried(coins []Stablecoin, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range coins {
+		if state, ok := m.state[c.ID]; ok {
+			state.tickersTriedAt = now
+		}
+	}
+}
+
+func (m *Monitor) evaluate(coins []Stablecoin, prices coingeckoapi.SimplePricesResponse, medians map[string]tickerMedian, now time.Time) []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Event
+	for _, c := range coins {
+		state, ok := m.state[c.ID]
+		if !ok {
+			// removed while polling
+			continue
+		}
+		if t, ok := medians[c.ID]; ok {
+			state.tickerMedian, state.tickers, state.tickersAt = t.value, t.count, now
+		} else if state.tickers > 0 && now.Sub(state.tickersAt) > m.cfg.tickerTTL() {
+			// tickers keep failing, a stale median must not hold the level
+			state.tickerMedian, state.tickers = decimal.Zero, 0
+		}
+		price, ok := prices[c.ID].Price[c.Peg]
+		if !ok {
+			continue
+		}
+		sample := Sample{
+			Time:         now,
+			Price:        price,
+			TickerMedian: state.tickerMedian,
+			Tickers:      state.tickers,
+			Deviation:    deviation(price),
+		}
+		if state.tickers > 0 {
+			if d := deviation(state.tickerMedian); d.Abs().GreaterThan(sample.Deviation.Abs()) {
+				sample.Deviation = d
+			}
+		}
+		state.history.add(sample, m.cfg.Keep)
+		if e, ok := m.grade(c, state, sample); ok {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// moves the coin's level once a deviation held for the sustain period
+func (m *Monitor) grade(c Stablecoin, state *coinState, s Sample) (Event, bool) {
+	abs := s.Deviation.Abs()
+	for _, level := range levels {
+		if abs.LessThan(m.cfg.threshold(level)) {
+			delete(state.reached, level)
+		} else if _, ok := state.reached[level]; !ok {
+			state.reached[level] = s.Time
+		}
+	}
+	target := LevelNone
+	for _, level := range levels {
+		if at, ok := state.reached[level]; ok && s.Time.Sub(at) >= m.cfg.Sustain {
+			target = level
+		}
+	}
+
+	if state.level != LevelNone && abs.LessThan(m.cfg.threshold(state.level)) {
+		if state.calm.IsZero() {
+			state.calm = s.Time
+		}
+	} else {
+		state.calm = time.Time{}
+	}
+
+	switch {
+	case rank(target) > rank(state.level):
+		e := Event{Coin: c, Level: target, Previous: state.level, Sample: s, Since: state.reached[target]}
+		state.level = target
+		return e, true
+	case rank(target) < rank(state.level) && !state.calm.IsZero() && s.Time.Sub(state.calm) >= m.cfg.Sustain:
+		e := Event{Coin: c, Level: target, Previous: state.level, Sample: s, Since: state.calm}
+		state.level = target
+		state.calm = time.Time{}
+		return e, true
+	}
+	return Event{}, false
+}
+
+func (m *Monitor) report(err error) {
+	select {
+	case m.errs <- err:
+	default:
+	}
+}
+
+type tickerMedian struct {
+	value decimal.Decimal
+	count int
+}
+
+// median converted_last in the peg currency, anomalies, stale and red trust tickers skipped
+// pegs other than btc, eth and usd convert from usd with the coin's own simple/price quotes
+func medianTicker(tickers []coingeckoapi.Ticker, peg string, quote coingeckoapi.SimplePriceQuote) tickerMedian {
+	unit, rate := peg, decimal.NewFromInt(1)
+	if !hasConverted(tickers, peg) {
+		pegPrice, ok1 := quote.Price[peg]
+		usdPrice, ok2 := quote.Price[tickerBaseVs]
+		if !ok1 || !ok2 || usdPrice.IsZero() {
+			return tickerMedian{}
+		}
+		unit, rate = tickerBaseVs, pegPrice.Div(usdPrice)
+	}
+	var values []decimal.Decimal
+	for _, t := range tickers {
+		if t.IsAnomaly || t.IsStale || t.TrustScore == "red" {
+			continue
+		}
+		if v, ok := t.ConvertedLast[unit]; ok && v.IsPositive() {
+			values = append(values, v.Mul(rate))
+		}
+	}
+	if len(values) == 0 {
+		return tickerMedian{}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].LessThan(values[j]) })
+	mid := len(values) / 2
+	median := values[mid]
+	if len(values)%2 == 0 {
+		median = values[mid-1].Add(values[mid]).Div(decimal.NewFromInt(2))
+	}
+	return tickerMedian{value: median, count: len(values)}
+}
+
+func hasConverted(tickers []coingeckoapi.Ticker, unit string) bool {
+	for _, t := range tickers {
+		if _, ok := t.ConvertedLast[unit]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// percent off a peg of 1
+func deviation(price decimal.Decimal) decimal.Decimal {
+	return price.Sub(decimal.NewFromInt(1)).Mul(decimal.NewFromInt(100))
+}
+
+func rank(level string) int {
+	for i, l := range levels {
+		if l == level {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func sortedIDs(m map[string]Stablecoin) []string {
+	out := make([]string, 0, len(m))
+	for id := range m {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func keys(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,184 @@
+package coingeckoapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+)
+
+// include tickers options for derivatives endpoints
+const (
+	DerivativesTickersAll       = "all"
+	DerivativesTickersUnexpired = "unexpired"
+)
+
+// order options for DerivativesExchanges
+const (
+	DerivativesExchangeOrderNameAsc             = "name_asc"
+	DerivativesExchangeOrderNameDesc            = "name_desc"
+	DerivativesExchangeOrderOpenInterestBtcAsc  = "open_interest_btc_asc"
+	DerivativesExchangeOrderOpenInterestBtcDesc = "open_interest_btc_desc"
+	DerivativesExchangeOrderVolumeBtcAsc        = "trade_volume_24h_btc_asc"
+	DerivativesExchangeOrderVolumeBtcDesc       = "trade_volume_24h_btc_desc"
+)
+
+// contract type values
+const (
+	ContractTypePerpetual = "perpetual"
+	ContractTypeFutures   = "futures"
+)
+
+type DerivativeResponse struct {
+	Market                   string          `json:"market"`
+	Symbol                   string          `json:"symbol"`
+	IndexID                  string          `json:"index_id"`
+	Price                    decimal.Decimal `json:"price"`
+	PricePercentageChange24h decimal.Decimal `json:"price_percentage_change_24h"`
+	ContractType             string          `json:"contract_type"`
+	Index                    decimal.Decimal `json:"index"`
+	Basis                    decimal.Decimal `json:"basis"`
+	Spread                   decimal.Decimal `json:"spread"`
+	FundingRate              decimal.Decimal `json:"funding_rate"`
+	OpenInterest             decimal.Decimal `json:"open_interest"`
+	Volume24h                decimal.Decimal `json:"volume_24h"`
+	LastTradedAt             UnixTime        `json:"last_traded_at"`
+	// zero for perpetual
+	ExpiredAt UnixTime `json:"expired_at"`
+}
+
+type DerivativeExchangeListResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type DerivativeExchangeResponse struct {
+	ID                     string          `json:"id"`
+	Name                   string          `json:"name"`
+	OpenInterestBtc        decimal.Decimal `json:"open_interest_btc"`
+	TradeVolume24hBtc      decimal.Decimal `json:"trade_volume_24h_btc"`
+	NumberOfPerpetualPairs int             `json:"number_of_perpetual_pairs"`
+	NumberOfFuturesPairs   int             `json:"number_of_futures_pairs"`
+	Image                  string          `json:"image"`
+	YearEstablished        int             `json:"year_established"`
+	Country                string          `json:"country"`
+	Description            string          `json:"description"`
+	URL                    string          `json:"url"`
+}
+
+type DerivativeExchangeDetailResponse struct {
+	Name                   string          `json:"name"`
+	OpenInterestBtc        decimal.Decimal `json:"open_interest_btc"`
+	TradeVolume24hBtc      decimal.Decimal `json:"trade_volume_24h_btc"`
+	NumberOfPerpetualPairs int             `json:"number_of_perpetual_pairs"`
+	NumberOfFuturesPairs   int             `json:"number_of_futures_pairs"`
+	Image                  string          `json:"image"`
+	YearEstablished        int             `json:"year_established"`
+	Country                string          `json:"country"`
+	Description            string          `json:"description"`
+	URL                    string          `json:"url"`
+	// empty unless include tickers is set
+	Tickers []DerivativeTicker `json:"tickers"`
+}
+
+type DerivativeTicker struct {
+	Symbol               string          `json:"symbol"`
+	Base                 string          `json:"base"`
+	Target               string          `json:"target"`
+	TradeURL             string          `json:"trade_url"`
+	ContractType         string          `json:"contract_type"`
+	Last                 decimal.Decimal `json:"last"`
+	H24PercentageChange  decimal.Decimal `json:"h24_percentage_change"`
+	Index                decimal.Decimal `json:"index"`
+	IndexBasisPercentage decimal.Decimal `json:"index_basis_percentage"`
+	BidAskSpread         decimal.Decimal `json:"bid_ask_spread"`
+	FundingRate          decimal.Decimal `json:"funding_rate"`
+	OpenInterestUsd      decimal.Decimal `json:"open_interest_usd"`
+	H24Volume            decimal.Decimal `json:"h24_volume"`
+	// keys are btc, eth, usd
+	ConvertedVolume map[string]decimal.Decimal `json:"converted_volume"`
+	ConvertedLast   map[string]decimal.Decimal `json:"converted_last"`
+	LastTraded      UnixTime                   `json:"last_traded"`
+	ExpiredAt       UnixTime                   `json:"expired_at"`
+}
+
+// includeTickers ex => DerivativesTickersUnexpired, empty for default
+func (b *Client) Derivatives(includeTickers string) ([]DerivativeResponse, error) {
+	type opt struct {
+		IncludeTickers string `url:"include_tickers,omitempty"`
+	}
+	input := opt{
+		IncludeTickers: includeTickers,
+	}
+	res, err := b.do("spot", http.MethodGet, "derivatives", input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []DerivativeResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// order ex => DerivativesExchangeOrderOpenInterestBtcDesc, empty for default
+// perPage and page 0 for default
+func (b *Client) DerivativesExchanges(order string, perPage, page int) ([]DerivativeExchangeResponse, error) {
+	type opt struct {
+		Order   string `url:"order,omitempty"`
+		PerPage int    `url:"per_page,omitempty"`
+		Page    int    `url:"page,omitempty"`
+	}
+	input := opt{
+		Order:   order,
+		PerPage: perPage,
+		Page:    page,
+	}
+	res, err := b.do("spot", http.MethodGet, "derivatives/exchanges", input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []DerivativeExchangeResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// exchangeID is from derivatives/exchanges/list endpoint
+// includeTickers ex => DerivativesTickersAll, empty for no tickers
+func (b *Client) DerivativesExchange(exchangeID, includeTickers string) (*DerivativeExchangeDetailResponse, error) {
+	type opt struct {
+		IncludeTickers string `url:"include_tickers,omitempty"`
+	}
+	input := opt{
+		IncludeTickers: includeTickers,
+	}
+	url := fmt.Sprintf("derivatives/exchanges/%s", exchangeID)
+	res, err := b.do("spot", http.MethodGet, url, input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := DerivativeExchangeDetailResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// all the derivatives exchange ids and names
+func (b *Client) DerivativesExchangesList() ([]DerivativeExchangeListResponse, error) {
+	res, err := b.do("spot", http.MethodGet, "derivatives/exchanges/list", nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []DerivativeExchangeListResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,216 @@
+package coingeckoapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// order options for ExchangeTickers
+const (
+	TickerOrderTrustScoreDesc = "trust_score_desc"
+	TickerOrderTrustScoreAsc  = "trust_score_asc"
+	TickerOrderVolumeDesc     = "volume_desc"
+	TickerOrderVolumeAsc      = "volume_asc"
+)
+
+const (
+	exchangesMaxPerPage = 250
+	tickersPerPage      = 100
+)
+
+type ExchangeListResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ExchangeResponse struct {
+	ID                          string          `json:"id"`
+	Name                        string          `json:"name"`
+	YearEstablished             int             `json:"year_established"`
+	Country                     string          `json:"country"`
+	Description                 string          `json:"description"`
+	URL                         string          `json:"url"`
+	Image                       string          `json:"image"`
+	HasTradingIncentive         bool            `json:"has_trading_incentive"`
+	TrustScore                  int             `json:"trust_score"`
+	TrustScoreRank              int             `json:"trust_score_rank"`
+	TradeVolume24hBtc           decimal.Decimal `json:"trade_volume_24h_btc"`
+	TradeVolume24hBtcNormalized decimal.Decimal `json:"trade_volume_24h_btc_normalized"`
+}
+
+type ExchangeDetailResponse struct {
+	Name                        string          `json:"name"`
+	YearEstablished             int             `json:"year_established"`
+	Country                     string          `json:"country"`
+	Description                 string          `json:"description"`
+	URL                         string          `json:"url"`
+	Image                       string          `json:"image"`
+	FacebookURL                 string          `json:"facebook_url"`
+	RedditURL                   string          `json:"reddit_url"`
+	TelegramURL                 string          `json:"telegram_url"`
+	SlackURL                    string          `json:"slack_url"`
+	OtherURL1                   string          `json:"other_url_1"`
+	OtherURL2                   string          `json:"other_url_2"`
+	TwitterHandle               string          `json:"twitter_handle"`
+	HasTradingIncentive         bool            `json:"has_trading_incentive"`
+	Centralized                 bool            `json:"centralized"`
+	PublicNotice                string          `json:"public_notice"`
+	AlertNotice                 string          `json:"alert_notice"`
+	TrustScore                  int             `json:"trust_score"`
+	TrustScoreRank              int             `json:"trust_score_rank"`
+	TradeVolume24hBtc           decimal.Decimal `json:"trade_volume_24h_btc"`
+	TradeVolume24hBtcNormalized decimal.Decimal `json:"trade_volume_24h_btc_normalized"`
+	// only the top 100 tickers, use ExchangeTickers for the rest
+	Tickers []Ticker `json:"tickers"`
+}
+
+type Ticker struct {
+	Base   string `json:"base"`
+	Target string `json:"target"`
+	Market struct {
+		Name                string `json:"name"`
+		Identifier          string `json:"identifier"`
+		HasTradingIncentive bool   `json:"has_trading_incentive"`
+		Logo                string `json:"logo"`
+	} `json:"market"`
+	Last   decimal.Decimal `json:"last"`
+	Volume decimal.Decimal `json:"volume"`
+	// only with depth option
+	CostToMoveUpUsd   decimal.Decimal `json:"cost_to_move_up_usd"`
+	CostToMoveDownUsd decimal.Decimal `json:"cost_to_move_down_usd"`
+	// keys are btc, eth, usd
+	ConvertedLast          map[string]decimal.Decimal `json:"converted_last"`
+	ConvertedVolume        map[string]decimal.Decimal `json:"converted_volume"`
+	TrustScore             string                     `json:"trust_score"`
+	BidAskSpreadPercentage decimal.Decimal            `json:"bid_ask_spread_percentage"`
+	Timestamp              time.Time                  `json:"timestamp"`
+	LastTradedAt           time.Time                  `json:"last_traded_at"`
+	LastFetchAt            time.Time                  `json:"last_fetch_at"`
+	IsAnomaly              bool                       `json:"is_anomaly"`
+	IsStale                bool                       `json:"is_stale"`
+	TradeURL               string                     `json:"trade_url"`
+	TokenInfoURL           string                     `json:"token_info_url"`
+	CoinID                 string                     `json:"coin_id"`
+	TargetCoinID           string                     `json:"target_coin_id"`
+}
+
+type ExchangeTickersResponse struct {
+	Name    string   `json:"name"`
+	Tickers []Ticker `json:"tickers"`
+}
+
+type ExchangeTickersOptions struct {
+	// comma-separated if filtering more than 1
+	CoinIDs             string `url:"coin_ids,omitempty"`
+	IncludeExchangeLogo bool   `url:"include_exchange_logo,omitempty"`
+	Page                int    `url:"page,omitempty"`
+	// include 2% orderbook depth, cost_to_move_up_usd and cost_to_move_down_usd
+	Depth bool `url:"depth,omitempty"`
+	// order ex => TickerOrderTrustScoreDesc
+	Order string `url:"order,omitempty"`
+}
+
+// perPage max is 250, page starts from 1
+func (b *Client) Exchanges(perPage, page int) ([]ExchangeResponse, error) {
+	type opt struct {
+		PerPage int `url:"per_page,omitempty"`
+		Page    int `url:"page,omitempty"`
+	}
+	input := opt{
+		PerPage: perPage,
+		Page:    page,
+	}
+	res, err := b.do("spot", http.MethodGet, "exchanges", input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []ExchangeResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// walks all the pages of exchanges endpoint
+func (b *Client) AllExchanges() ([]ExchangeResponse, error) {
+	var out []ExchangeResponse
+	for page := 1; ; page++ {
+		result, err := b.Exchanges(exchangesMaxPerPage, page)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, result...)
+		if len(result) < exchangesMaxPerPage {
+			return out, nil
+		}
+	}
+}
+
+// all the exchange ids and names, no market data
+func (b *Client) ExchangesList() ([]ExchangeListResponse, error) {
+	res, err := b.do("spot", http.MethodGet, "exchanges/list", nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []ExchangeListResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// exchangeID is from exchanges/list endpoint
+func (b *Client) Exchange(exchangeID string) (*ExchangeDetailResponse, error) {
+	url := fmt.Sprintf("exchanges/%s", exchangeID)
+	res, err := b.do("spot", http.MethodGet, url, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := ExchangeDetailResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 100 tickers per page, opt can be nil
+func (b *Client) ExchangeTickers(exchangeID string, opt *ExchangeTickersOptions) (*ExchangeTickersResponse, error) {
+	url := fmt.Sprintf("exchanges/%s/tickers", exchangeID)
+	res, err := b.do("spot", http.MethodGet, url, opt, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := ExchangeTickersResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// walks all the pages of exchange tickers, opt.Page is ignored
+func (b *Client) AllExchangeTickers(exchangeID string, opt *ExchangeTickersOptions) (*ExchangeTickersResponse, error) {
+	input := ExchangeTickersOptions{}
+	if opt != nil {
+		input = *opt
+	}
+	out := ExchangeTickersResponse{}
+	for page := 1; ; page++ {
+		input.Page = page
+		result, err := b.ExchangeTickers(exchangeID, &input)
+		if err != nil {
+			return nil, err
+		}
+		out.Name = result.Name
+		out.Tickers = append(out.Tickers, result.Tickers...)
+		if len(result.Tickers) < tickersPerPage {
+			return &out, nil
+		}
+	}
+}
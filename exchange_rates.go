@@ -0,0 +1,175 @@
+package coingeckoapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// exchange rate type values
+const (
+	RateTypeFiat      = "fiat"
+	RateTypeCrypto    = "crypto"
+	RateTypeCommodity = "commodity"
+)
+
+type ExchangeRate struct {
+	Name string `json:"name"`
+	Unit string `json:"unit"`
+	// how many of this unit for 1 btc
+	Value decimal.Decimal `json:"value"`
+	Type  string          `json:"type"`
+}
+
+// unit key ex => usd, eur, sats
+type ExchangeRatesResponse map[string]ExchangeRate
+
+// btc to everything
+func (b *Client) ExchangeRates() (ExchangeRatesResponse, error) {
+	res, err := b.do("spot", http.MethodGet, "exchange_rates", nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Rates ExchangeRatesResponse `json:"rates"`
+	}{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Rates, nil
+}
+
+// a failed refresh is not retried sooner than this, readers get the last table meanwhile
+const converterRetry = 10 * time.Second
+
+// converts amounts between any units of exchange_rates through btc cross rates
+// safe for concurrent use
+type Converter struct {
+	client  *Client
+	refresh time.Duration
+	errs    chan error
+
+	// only one goroutine fetches the table at a time
+	fetchMu  sync.Mutex
+	failedAt time.Time
+
+	mu        sync.RWMutex
+	rates     ExchangeRatesResponse
+	updatedAt time.Time
+}
+
+// the rate table is fetched again once older than refresh
+func NewConverter(client *Client, refresh time.Duration) (*Converter, error) {
+	if refresh <= 0 {
+		return nil, errors.New("converter refresh must be positive")
+	}
+	return &Converter{
+		client:  client,
+		refresh: refresh,
+		errs:    make(chan error, 1),
+	}, nil
+}
+
+// refreshes the table every refresh interval until ctx is done, so readers never wait on a fetch
+// optional, without it the table is refreshed on read
+func (c *Converter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.refresh)
+	defer ticker.Stop()
+	for {
+		if err := c.Refresh(); err != nil {
+			select {
+			case c.errs <- err:
+			default:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// failed background refreshes of Run, later ones are dropped while one is unread
+func (c *Converter) Errors() <-chan error {
+	return c.errs
+}
+
+// from and to ex => usd, eur, sats, btc
+func (c *Converter) Convert(amount decimal.Decimal, from, to string) (decimal.Decimal, error) {
+	rates, err := c.Rates()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	fromRate, ok := rates[strings.ToLower(from)]
+	if !ok {
+		return decimal.Zero, errors.New(fmt.Sprintf("un-support unit: %s", from))
+	}
+	toRate, ok := rates[strings.ToLower(to)]
+	if !ok {
+		return decimal.Zero, errors.New(fmt.Sprintf("un-support unit: %s", to))
+	}
+	if fromRate.Value.IsZero() {
+		return decimal.Zero, errors.New(fmt.Sprintf("zero rate for unit: %s", from))
+	}
+	return amount.Mul(toRate.Value).Div(fromRate.Value), nil
+}
+
+// current rate table, fetched if missing or stale
+// when the fetch fails the last table is returned, check UpdatedAt for its age
+// the returned map is shared, do not modify it
+func (c *Converter) Rates() (ExchangeRatesResponse, error) {
+	c.mu.RLock()
+	rates, updatedAt := c.rates, c.updatedAt
+	c.mu.RUnlock()
+	if rates != nil && time.Since(updatedAt) < c.refresh {
+		return rates, nil
+	}
+
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+	// someone else may have refreshed while waiting
+	c.mu.RLock()
+	rates, updatedAt = c.rates, c.updatedAt
+	c.mu.RUnlock()
+	if rates != nil && (time.Since(updatedAt) < c.refresh || time.Since(c.failedAt) < converterRetry) {
+		return rates, nil
+	}
+	if err := c.Refresh(); err != nil {
+		c.failedAt = time.Now()
+		if rates != nil {
+			return rates, nil
+		}
+		return nil, err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.rates, nil
+}
+
+// fetches the rate table now
+func (c *Converter) Refresh() error {
+	rates, err := c.client.ExchangeRates()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.rates = rates
+	c.updatedAt = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// when the rate table was last fetched
+func (c *Converter) UpdatedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.updatedAt
+}
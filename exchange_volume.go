@@ -0,0 +1,199 @@
+package coingeckoapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// gap between paced calls, the public api allows about 30 calls per minute
+const DefaultCallInterval = 2 * time.Second
+
+// days ex => 1, 7, 14, 30, 90, 180, 365
+// values are btc volume
+func (b *Client) ExchangeVolumeChart(exchangeID string, days int) ([]SeriesPoint, error) {
+	return b.ExchangeVolumeChartContext(context.Background(), exchangeID, days)
+}
+
+func (b *Client) ExchangeVolumeChartContext(ctx context.Context, exchangeID string, days int) ([]SeriesPoint, error) {
+	type opt struct {
+		Days int `url:"days"`
+	}
+	input := opt{
+		Days: days,
+	}
+	url := fmt.Sprintf("exchanges/%s/volume_chart", exchangeID)
+	res, err := b.doContext(ctx, "spot", http.MethodGet, url, input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []SeriesPoint{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// paid plan only, values are btc volume
+func (b *Client) ExchangeVolumeChartRange(exchangeID string, from, to time.Time) ([]SeriesPoint, error) {
+	return b.ExchangeVolumeChartRangeContext(context.Background(), exchangeID, from, to)
+}
+
+func (b *Client) ExchangeVolumeChartRangeContext(ctx context.Context, exchangeID string, from, to time.Time) ([]SeriesPoint, error) {
+	type opt struct {
+		From int64 `url:"from"`
+		To   int64 `url:"to"`
+	}
+	input := opt{
+		From: from.Unix(),
+		To:   to.Unix(),
+	}
+	url := fmt.Sprintf("exchanges/%s/volume_chart/range", exchangeID)
+	res, err := b.doContext(ctx, "spot", http.MethodGet, url, input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []SeriesPoint{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// exchanges volume on the timestamps they all have in common
+type VolumeTable struct {
+	Times []time.Time
+	// exchange id => btc volume, same index as Times
+	Volumes map[string][]decimal.Decimal
+}
+
+// fetches the volume chart of each exchange concurrently, one call started per interval at most
+// interval 0 means the public api limit
+// timestamps are truncated to bucket before aligning, bucket 0 means exact match
+func (b *Client) ExchangeVolumeCharts(exchangeIDs []string, days int, interval, bucket time.Duration) (*VolumeTable, error) {
+	return b.ExchangeVolumeChartsContext(context.Background(), exchangeIDs, days, interval, bucket)
+}
+
+// stops starting calls once ctx is done, calls in flight are cancelled with it
+func (b *Client) ExchangeVolumeChartsContext(ctx context.Context, exchangeIDs []string, days int, interval, bucket time.Duration) (*VolumeTable, error) {
+	if interval <= 0 {
+		interval = DefaultCallInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	type result struct {
+		id     string
+		points []SeriesPoint
+		err    error
+	}
+	results := make(chan result, len(exchangeIDs))
+	var wg sync.WaitGroup
+	for i, id := range exchangeIDs {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+			case <-ticker.C:
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			points, err := b.ExchangeVolumeChartContext(ctx, id, days)
+			results <- result{id: id, points: points, err: err}
+		}(id)
+	}
+	wg.Wait()
+	close(results)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	series := make(map[string][]SeriesPoint, len(exchangeIDs))
+	for r := range results {
+		if r.err != nil {
+			return nil, fmt.Errorf("%s: %w", r.id, r.err)
+		}
+		series[r.id] = r.points
+	}
+	return AlignVolumes(series, bucket), nil
+}
+
+// keeps only the timestamps every series has, bucket 0 means exact match
+// points of one series falling in the same bucket are summed
+func AlignVolumes(series map[string][]SeriesPoint, bucket time.Duration) *VolumeTable {
+	counts := make(map[int64]int)
+	values := make(map[string]map[int64]decimal.Decimal, len(series))
+	for id, points := range series {
+		values[id] = make(map[int64]decimal.Decimal, len(points))
+		for _, p := range points {
+			t := p.Time
+			if bucket > 0 {
+				t = t.Truncate(bucket)
+			}
+			key := t.UnixNano()
+			prev, ok := values[id][key]
+			if !ok {
+				counts[key]++
+			}
+			if ok && bucket > 0 {
+				values[id][key] = prev.Add(p.Value)
+			} else {
+				values[id][key] = p.Value
+			}
+		}
+	}
+	keys := []int64{}
+	for key, n := range counts {
+		if n == len(series) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	out := VolumeTable{
+		Times:   make([]time.Time, len(keys)),
+		Volumes: make(map[string][]decimal.Decimal, len(series)),
+	}
+	for i, key := range keys {
+		out.Times[i] = time.Unix(0, key)
+	}
+	for id := range series {
+		vols := make([]decimal.Decimal, len(keys))
+		for i, key := range keys {
+			vols[i] = values[id][key]
+		}
+		out.Volumes[id] = vols
+	}
+	return &out
+}
+
+// share of the total volume for exchangeID on each timestamp, zero when total is zero
+func (t *VolumeTable) Share(exchangeID string) []decimal.Decimal {
+	vols, ok := t.Volumes[exchangeID]
+	if !ok {
+		return nil
+	}
+	out := make([]decimal.Decimal, len(t.Times))
+	for i := range t.Times {
+		total := decimal.Zero
+		for _, v := range t.Volumes {
+			total = total.Add(v[i])
+		}
+		if total.IsZero() {
+			continue
+		}
+		out[i] = vols[i].Div(total)
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// streams records as csv rows, header goes before the first row
+type CSVWriter struct {
+	w          *csv.Writer
+	kind       string
+	fields     []field
+	timeFormat string
+	header     bool
+}
+
+// kind ex => KindSeries
+func NewCSV(w io.Writer, kind string, opt Options) (*CSVWriter, error) {
+	fields, err := selectFields(kind, opt.Columns)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTimeFormat(opt.TimeFormat); err != nil {
+		return nil, err
+	}
+	return &CSVWriter{
+		w:          csv.NewWriter(w),
+		kind:       kind,
+		fields:     fields,
+		timeFormat: opt.TimeFormat,
+	}, nil
+}
+
+func (c *CSVWriter) Write(rec interface{}) error {
+	rec, err := normalize(c.kind, rec)
+	if err != nil {
+		return err
+	}
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	row := make([]string, len(c.fields))
+	for i, f := range c.fields {
+		row[i] = formatValue(f.get(rec), c.timeFormat)
+	}
+	return c.w.Write(row)
+}
+
+// writes the header even with no rows, and flushes
+func (c *CSVWriter) Flush() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *CSVWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+	c.header = true
+	names := make([]string, len(c.fields))
+	for i, f := range c.fields {
+		names[i] = f.name
+	}
+	return c.w.Write(names)
+}
@@ -0,0 +1,221 @@
+package export
+
+import (
+	"fmt"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+// record kinds, each writer handles one
+const (
+	// coingeckoapi.SeriesPoint, from market chart and volume chart
+	KindSeries = "series"
+	// coingeckoapi.Candle
+	KindCandle = "candle"
+	// coingeckoapi.CoinMarketResponse
+	KindMarket = "market"
+	// coingeckoapi.Ticker
+	KindTicker = "ticker"
+	// coingeckoapi.CoinListResponse
+	KindCoinList = "coin_list"
+)
+
+// field getters return string, decimal.Decimal, decimal.NullDecimal, time.Time, int, *int or bool
+// an invalid NullDecimal or nil *int is written blank in csv and null in ndjson
+type field struct {
+	name string
+	get  func(rec interface{}) interface{}
+}
+
+var kindFields = map[string][]field{
+	KindSeries: {
+		{"time", func(r interface{}) interface{} { return r.(*coingeckoapi.SeriesPoint).Time }},
+		{"value", func(r interface{}) interface{} { return r.(*coingeckoapi.SeriesPoint).Value }},
+	},
+	KindCandle: {
+		{"time", func(r interface{}) interface{} { return r.(*coingeckoapi.Candle).Time }},
+		{"open", func(r interface{}) interface{} { return r.(*coingeckoapi.Candle).Open }},
+		{"high", func(r interface{}) interface{} { return r.(*coingeckoapi.Candle).High }},
+		{"low", func(r interface{}) interface{} { return r.(*coingeckoapi.Candle).Low }},
+		{"close", func(r interface{}) interface{} { return r.(*coingeckoapi.Candle).Close }},
+		{"volume", func(r interface{}) interface{} { return r.(*coingeckoapi.Candle).Volume }},
+	},
+	KindMarket: {
+		{"id", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).ID }},
+		{"symbol", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).Symbol }},
+		{"name", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).Name }},
+		{"current_price", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).CurrentPrice }},
+		{"market_cap", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).MarketCap }},
+		{"market_cap_rank", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).MarketCapRank }},
+		{"fully_diluted_valuation", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).FullyDilutedValuation }},
+		{"total_volume", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).TotalVolume }},
+		{"high_24h", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).High24h }},
+		{"low_24h", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).Low24h }},
+		{"price_change_24h", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).PriceChange24h }},
+		{"price_change_percentage_24h", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).PriceChangePercentage24h }},
+		{"circulating_supply", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).CirculatingSupply }},
+		{"total_supply", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).TotalSupply }},
+		{"max_supply", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).MaxSupply }},
+		{"ath", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).Ath }},
+		{"ath_date", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).AthDate }},
+		{"atl", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).Atl }},
+		{"atl_date", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).AtlDate }},
+		{"last_updated", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinMarketResponse).LastUpdated }},
+	},
+	KindTicker: {
+		{"base", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).Base }},
+		{"target", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).Target }},
+		{"market", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).Market.Identifier }},
+		{"last", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).Last }},
+		{"volume", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).Volume }},
+		{"converted_last_usd", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).ConvertedLast["usd"] }},
+		{"converted_volume_usd", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).ConvertedVolume["usd"] }},
+		{"trust_score", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).TrustScore }},
+		{"bid_ask_spread_percentage", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).BidAskSpreadPercentage }},
+		{"timestamp", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).Timestamp }},
+		{"last_traded_at", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).LastTradedAt }},
+		{"is_anomaly", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).IsAnomaly }},
+		{"is_stale", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).IsStale }},
+		{"coin_id", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).CoinID }},
+		{"target_coin_id", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).TargetCoinID }},
+		{"trade_url", func(r interface{}) interface{} { return r.(*coingeckoapi.Ticker).TradeURL }},
+	},
+	KindCoinList: {
+		{"id", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinListResponse).ID }},
+		{"symbol", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinListResponse).Symbol }},
+		{"name", func(r interface{}) interface{} { return r.(*coingeckoapi.CoinListResponse).Name }},
+	},
+}
+
+// column names of kind, in default order
+func Columns(kind string) []string {
+	fields := kindFields[kind]
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		out = append(out, f.name)
+	}
+	return out
+}
+
+// picks the fields of columns, all of them when columns is empty
+func selectFields(kind string, columns []string) ([]field, error) {
+	fields, ok := kindFields[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown kind: %s", kind)
+	}
+	if len(columns) == 0 {
+		return fields, nil
+	}
+	out := make([]field, 0, len(columns))
+	for _, name := range columns {
+		found := false
+		for _, f := range fields {
+			if f.name == name {
+				out = append(out, f)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown %s column: %s", kind, name)
+		}
+	}
+	return out, nil
+}
+
+// records are taken by value or pointer, always handed to getters as pointer
+func normalize(kind string, rec interface{}) (interface{}, error) {
+	switch r := rec.(type) {
+	case coingeckoapi.SeriesPoint:
+		rec = &r
+	case coingeckoapi.Candle:
+		rec = &r
+	case coingeckoapi.CoinMarketResponse:
+		rec = &r
+	case coingeckoapi.Ticker:
+		rec = &r
+	case coingeckoapi.CoinListResponse:
+		rec = &r
+	}
+	ok := false
+	switch kind {
+	case KindSeries:
+		_, ok = rec.(*coingeckoapi.SeriesPoint)
+	case KindCandle:
+		_, ok = rec.(*coingeckoapi.Candle)
+	case KindMarket:
+		_, ok = rec.(*coingeckoapi.CoinMarketResponse)
+	case KindTicker:
+		_, ok = rec.(*coingeckoapi.Ticker)
+	case KindCoinList:
+		_, ok = rec.(*coingeckoapi.CoinListResponse)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%T is not a %s record", rec, kind)
+	}
+	return rec, nil
+}
+
+// time format options
+const (
+	TimeRFC3339 = "rfc3339"
+	// seconds
+	TimeUnix = "unix"
+	// milliseconds, as coingecko sends them
+	TimeUnixMs = "unix_ms"
+)
+
+type Options struct {
+	// column names from Columns, empty for all
+	Columns []string
+	// ex => TimeRFC3339, empty for rfc3339
+	TimeFormat string
+}
+
+func checkTimeFormat(format string) error {
+	switch format {
+	case "", TimeRFC3339, TimeUnix, TimeUnixMs:
+		return nil
+	}
+	return fmt.Errorf("unknown time format: %s", format)
+}
+
+func formatTime(t time.Time, format string) string {
+	if t.IsZero() {
+		return ""
+	}
+	switch format {
+	case TimeUnix:
+		return fmt.Sprintf("%d", t.Unix())
+	case TimeUnixMs:
+		return fmt.Sprintf("%d", t.UnixNano()/int64(time.Millisecond))
+	default:
+		return t.UTC().Format(time.RFC3339)
+	}
+}
+
+// decimals go through String so nothing is lost to float64
+func formatValue(v interface{}, timeFormat string) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case decimal.Decimal:
+		return x.String()
+	case decimal.NullDecimal:
+		if !x.Valid {
+			return ""
+		}
+		return x.Decimal.String()
+	case *int:
+		if x == nil {
+			return ""
+		}
+		return fmt.Sprint(*x)
+	case time.Time:
+		return formatTime(x, timeFormat)
+	default:
+		return fmt.Sprint(x)
+	}
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// streams records as one json object per line
+// decimals are written as json numbers from their exact string form
+type NDJSONWriter struct {
+	w          *bufio.Writer
+	kind       string
+	fields     []field
+	timeFormat string
+}
+
+// kind ex => KindSeries
+func NewNDJSON(w io.Writer, kind string, opt Options) (*NDJSONWriter, error) {
+	fields, err := selectFields(kind, opt.Columns)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTimeFormat(opt.TimeFormat); err != nil {
+		return nil, err
+	}
+	return &NDJSONWriter{
+		w:          bufio.NewWriter(w),
+		kind:       kind,
+		fields:     fields,
+		timeFormat: opt.TimeFormat,
+	}, nil
+}
+
+func (n *NDJSONWriter) Write(rec interface{}) error {
+	rec, err := normalize(n.kind, rec)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 0, 256)
+	buf = append(buf, '{')
+	for i, f := range n.fields {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendQuote(buf, f.name)
+		buf = append(buf, ':')
+		buf, err = n.appendValue(buf, f.get(rec))
+		if err != nil {
+			return err
+		}
+	}
+	buf = append(buf, '}', '\n')
+	_, err = n.w.Write(buf)
+	return err
+}
+
+func (n *NDJSONWriter) Flush() error {
+	return n.w.Flush()
+}
+
+func (n *NDJSONWriter) appendValue(buf []byte, v interface{}) ([]byte, error) {
+	switch x := v.(type) {
+	case decimal.Decimal:
+		return append(buf, x.String()...), nil
+	case decimal.NullDecimal:
+		if !x.Valid {
+			return append(buf, "null"...), nil
+		}
+		return append(buf, x.Decimal.String()...), nil
+	case time.Time:
+		s := formatTime(x, n.timeFormat)
+		switch {
+		case s == "":
+			return append(buf, "null"...), nil
+		case n.timeFormat == TimeUnix || n.timeFormat == TimeUnixMs:
+			return append(buf, s...), nil
+		default:
+			return strconv.AppendQuote(buf, s), nil
+		}
+	default:
+		b, err := json.Marshal(x)
+		if err != nil {
+			return nil, err
+		}
+		return append(buf, b...), nil
+	}
+}
@@ -0,0 +1,70 @@
+package coingeckoapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type GlobalResponse struct {
+	ActiveCryptocurrencies int `json:"active_cryptocurrencies"`
+	UpcomingIcos           int `json:"upcoming_icos"`
+	OngoingIcos            int `json:"ongoing_icos"`
+	EndedIcos              int `json:"ended_icos"`
+	Markets                int `json:"markets"`
+	// quote currency => value, ex => usd
+	TotalMarketCap map[string]decimal.Decimal `json:"total_market_cap"`
+	TotalVolume    map[string]decimal.Decimal `json:"total_volume"`
+	// coin symbol => dominance in percent, ex => btc
+	MarketCapPercentage             map[string]decimal.Decimal `json:"market_cap_percentage"`
+	MarketCapChangePercentage24hUsd decimal.Decimal            `json:"market_cap_change_percentage_24h_usd"`
+	UpdatedAt                       time.Time                  `json:"-"`
+}
+
+type GlobalDefiResponse struct {
+	DefiMarketCap        decimal.Decimal `json:"defi_market_cap"`
+	EthMarketCap         decimal.Decimal `json:"eth_market_cap"`
+	DefiToEthRatio       decimal.Decimal `json:"defi_to_eth_ratio"`
+	TradingVolume24h     decimal.Decimal `json:"trading_volume_24h"`
+	DefiDominance        decimal.Decimal `json:"defi_dominance"`
+	TopCoinName          string          `json:"top_coin_name"`
+	TopCoinDefiDominance decimal.Decimal `json:"top_coin_defi_dominance"`
+}
+
+// total market cap, volume and dominance across all coins
+func (b *Client) Global() (*GlobalResponse, error) {
+	res, err := b.do("spot", http.MethodGet, "global", nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Data struct {
+			GlobalResponse
+			UpdatedAt UnixTime `json:"updated_at"`
+		} `json:"data"`
+	}{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	out := result.Data.GlobalResponse
+	out.UpdatedAt = result.Data.UpdatedAt.Time
+	return &out, nil
+}
+
+// defi figures come as strings, all parsed to decimal
+func (b *Client) GlobalDefi() (*GlobalDefiResponse, error) {
+	res, err := b.do("spot", http.MethodGet, "global/decentralized_finance_defi", nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Data GlobalDefiResponse `json:"data"`
+	}{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Data, nil
+}
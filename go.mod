@@ -5,6 +5,7 @@ go 1.17
 require (
 	github.com/google/go-querystring v1.1.0
 	github.com/json-iterator/go v1.1.12
+	github.com/shopspring/decimal v1.4.0
 )
 
 require (
@@ -0,0 +1,65 @@
+package indicators
+
+import (
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+// wilder's average true range
+type ATR struct {
+	period    int
+	n         decimal.Decimal
+	prevClose decimal.Decimal
+	count     int
+	value     decimal.Decimal
+}
+
+func NewATR(period int) (*ATR, error) {
+	if err := checkPeriod("atr", period); err != nil {
+		return nil, err
+	}
+	return &ATR{
+		period: period,
+		n:      decimal.NewFromInt(int64(period)),
+	}, nil
+}
+
+// false until period candles are in
+func (a *ATR) Update(c coingeckoapi.Candle) (decimal.Decimal, bool) {
+	tr := c.High.Sub(c.Low)
+	if a.count > 0 {
+		if up := c.High.Sub(a.prevClose).Abs(); up.GreaterThan(tr) {
+			tr = up
+		}
+		if down := c.Low.Sub(a.prevClose).Abs(); down.GreaterThan(tr) {
+			tr = down
+		}
+	}
+	a.prevClose = c.Close
+	a.count++
+	switch {
+	case a.count < a.period:
+		a.value = a.value.Add(tr)
+		return decimal.Zero, false
+	case a.count == a.period:
+		// first average is a plain mean
+		a.value = a.value.Add(tr).Div(a.n)
+	default:
+		a.value = a.value.Mul(a.n.Sub(decimal.NewFromInt(1))).Add(tr).Div(a.n)
+	}
+	return a.value, true
+}
+
+func ATRSeries(candles []coingeckoapi.Candle, period int) ([]coingeckoapi.SeriesPoint, error) {
+	a, err := NewATR(period)
+	if err != nil {
+		return nil, err
+	}
+	var out []coingeckoapi.SeriesPoint
+	for _, c := range candles {
+		if v, ok := a.Update(c); ok {
+			out = append(out, coingeckoapi.SeriesPoint{Time: c.Time, Value: v})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,34 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+func TestATRSteadyTrend(t *testing.T) {
+	// closes rise by 3 with high and low 1 away, so the first true range is 2 and
+	// every later one is 4. the first average is 54/14 and each step closes
+	// 1/14 of the remaining distance to 4
+	var rows []coingeckoapi.Candle
+	for i := 0; i < 30; i++ {
+		c := 10 + 3*float64(i)
+		rows = append(rows, coingeckoapi.Candle{
+			Time:  start.AddDate(0, 0, i),
+			High:  decimal.NewFromFloat(c + 1),
+			Low:   decimal.NewFromFloat(c - 1),
+			Close: decimal.NewFromFloat(c),
+		})
+	}
+	var want []float64
+	for j := 0; j <= 30-14; j++ {
+		want = append(want, 4-2.0/14*math.Pow(13.0/14, float64(j)))
+	}
+	got, err := ATRSeries(rows, 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSeries(t, "atr", got, want, 12, start.AddDate(0, 0, 13))
+}
@@ -0,0 +1,99 @@
+package indicators
+
+import (
+	"math"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+// digits kept by the square root
+const sqrtPrecision = 16
+
+type Band struct {
+	Upper  decimal.Decimal
+	Middle decimal.Decimal
+	Lower  decimal.Decimal
+}
+
+type BandPoint struct {
+	Time time.Time
+	Band
+}
+
+// sma middle band, k population standard deviations around it, usually 20, 2
+type Bollinger struct {
+	sma *SMA
+	k   decimal.Decimal
+}
+
+func NewBollinger(period int, k decimal.Decimal) (*Bollinger, error) {
+	if err := checkPeriod("bollinger", period); err != nil {
+		return nil, err
+	}
+	sma, err := NewSMA(period)
+	if err != nil {
+		return nil, err
+	}
+	return &Bollinger{
+		sma: sma,
+		k:   k,
+	}, nil
+}
+
+// false until period values are in
+func (b *Bollinger) Update(v decimal.Decimal) (Band, bool) {
+	mean, ok := b.sma.Update(v)
+	if !ok {
+		return Band{}, false
+	}
+	variance := decimal.Zero
+	for _, x := range b.sma.window {
+		d := x.Sub(mean)
+		variance = variance.Add(d.Mul(d))
+	}
+	variance = variance.Div(decimal.NewFromInt(int64(len(b.sma.window))))
+	width := sqrt(variance).Mul(b.k)
+	return Band{
+		Upper:  mean.Add(width),
+		Middle: mean,
+		Lower:  mean.Sub(width),
+	}, true
+}
+
+func BollingerSeries(points []coingeckoapi.SeriesPoint, period int, k decimal.Decimal) ([]BandPoint, error) {
+	b, err := NewBollinger(period, k)
+	if err != nil {
+		return nil, err
+	}
+	var out []BandPoint
+	for _, p := range points {
+		if v, ok := b.Update(p.Value); ok {
+			out = append(out, BandPoint{Time: p.Time, Band: v})
+		}
+	}
+	return out, nil
+}
+
+// newton's method, x must not be negative
+func sqrt(x decimal.Decimal) decimal.Decimal {
+	if !x.IsPositive() {
+		return decimal.Zero
+	}
+	// float guess, then refine in decimal
+	f, _ := x.Float64()
+	guess := decimal.NewFromFloat(math.Sqrt(f))
+	if !guess.IsPositive() {
+		guess = x
+	}
+	two := decimal.NewFromInt(2)
+	for i := 0; i < 50; i++ {
+		next := guess.Add(x.DivRound(guess, sqrtPrecision+4)).DivRound(two, sqrtPrecision+4)
+		if next.Sub(guess).Abs().LessThan(decimal.New(1, -sqrtPrecision)) {
+			return next.Round(sqrtPrecision)
+		}
+		guess = next
+	}
+	return guess.Round(sqrtPrecision)
+}
@@ -0,0 +1,59 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBollingerRamp(t *testing.T) {
+	// 20 consecutive integers have population variance (20^2 - 1) / 12 = 33.25
+	const halfWidth = 11.532562594670797 // 2 * sqrt(33.25)
+	got, err := BollingerSeries(points(ramp(40)), 20, decimal.NewFromInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 21 {
+		t.Fatalf("got %d points, want 21", len(got))
+	}
+	if first := start.AddDate(0, 0, 19); !got[0].Time.Equal(first) {
+		t.Errorf("first point at %v, want %v", got[0].Time, first)
+	}
+	for i, g := range got {
+		mid := float64(i+19) - 9.5
+		if !near(g.Middle, mid, 12) || !near(g.Upper, mid+halfWidth, 12) || !near(g.Lower, mid-halfWidth, 12) {
+			t.Errorf("bollinger[%d] = %s %s %s, want %v +- %v", i, g.Middle, g.Upper, g.Lower, mid, halfWidth)
+		}
+	}
+}
+
+func TestBollingerConstant(t *testing.T) {
+	values := make([]float64, 25)
+	for i := range values {
+		values[i] = 42.5
+	}
+	got, err := BollingerSeries(points(values), 20, decimal.NewFromInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := decimal.NewFromFloat(42.5)
+	for i, g := range got {
+		if !g.Upper.Equal(want) || !g.Middle.Equal(want) || !g.Lower.Equal(want) {
+			t.Errorf("bollinger[%d] = %s %s %s, want a zero width band at 42.5", i, g.Upper, g.Middle, g.Lower)
+		}
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	for _, c := range []struct{ x, want string }{
+		{"0", "0"},
+		{"4", "2"},
+		{"2", "1.4142135623730950"},
+		{"0.0001", "0.01"},
+	} {
+		got := sqrt(decimal.RequireFromString(c.x))
+		if !got.Equal(decimal.RequireFromString(c.want)) {
+			t.Errorf("sqrt(%s) = %s, want %s", c.x, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package indicators
+
+import (
+	"testing"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+// rsi expects wilder's published values for his 14 period sample. the other
+// indicators are checked on series with a closed form: on a ramp of step 1 an
+// n period sma or ema trails by (n-1)/2, so macd and the band width are constant
+
+// wilder's 14 period sample, as on the stockcharts rsi worksheet
+var rsiCloses = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08,
+	45.89, 46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64,
+	46.21, 46.25, 45.71, 46.45, 45.78, 45.35, 44.03, 44.18, 44.22, 44.57,
+	43.42, 42.66, 43.13,
+}
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func points(values []float64) []coingeckoapi.SeriesPoint {
+	out := make([]coingeckoapi.SeriesPoint, len(values))
+	for i, v := range values {
+		out[i] = coingeckoapi.SeriesPoint{Time: start.AddDate(0, 0, i), Value: decimal.NewFromFloat(v)}
+	}
+	return out
+}
+
+// 0, 1, 2, ... n-1
+func ramp(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = float64(i)
+	}
+	return out
+}
+
+// got must be within half a unit of want's last place
+func near(got decimal.Decimal, want float64, places int32) bool {
+	return got.Sub(decimal.NewFromFloat(want)).Abs().LessThanOrEqual(decimal.New(5, -places-1))
+}
+
+// series values against expected values, and the first expected value at the warm-up index
+func checkSeries(t *testing.T, name string, got []coingeckoapi.SeriesPoint, want []float64, places int32, firstTime time.Time) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d points, want %d", name, len(got), len(want))
+	}
+	if !got[0].Time.Equal(firstTime) {
+		t.Errorf("%s: first point at %v, want %v", name, got[0].Time, firstTime)
+	}
+	for i, w := range want {
+		if !near(got[i].Value, w, places) {
+			t.Errorf("%s[%d] = %s, want %.*f", name, i, got[i].Value.StringFixed(places+2), places, w)
+		}
+	}
+}
+
+func TestPeriodMustBePositive(t *testing.T) {
+	for _, period := range []int{0, -1} {
+		if _, err := NewSMA(period); err == nil {
+			t.Errorf("NewSMA(%d) accepted", period)
+		}
+		if _, err := NewEMA(period); err == nil {
+			t.Errorf("NewEMA(%d) accepted", period)
+		}
+		if _, err := NewRSI(period); err == nil {
+			t.Errorf("NewRSI(%d) accepted", period)
+		}
+		if _, err := NewATR(period); err == nil {
+			t.Errorf("NewATR(%d) accepted", period)
+		}
+		if _, err := NewBollinger(period, decimal.NewFromInt(2)); err == nil {
+			t.Errorf("NewBollinger(%d) accepted", period)
+		}
+		if _, err := NewMACD(12, period, 9); err == nil {
+			t.Errorf("NewMACD(12, %d, 9) accepted", period)
+		}
+		if _, err := SMASeries(points(ramp(30)), period); err == nil {
+			t.Errorf("SMASeries(%d) accepted", period)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package indicators
+
+import (
+	"fmt"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+// simple moving average over the last period values
+type SMA struct {
+	period int
+	window []decimal.Decimal
+	sum    decimal.Decimal
+}
+
+func NewSMA(period int) (*SMA, error) {
+	if err := checkPeriod("sma", period); err != nil {
+		return nil, err
+	}
+	return &SMA{
+		period: period,
+		window: make([]decimal.Decimal, 0, period),
+	}, nil
+}
+
+// false until period values are in
+func (s *SMA) Update(v decimal.Decimal) (decimal.Decimal, bool) {
+	s.sum = s.sum.Add(v)
+	s.window = append(s.window, v)
+	if len(s.window) > s.period {
+		s.sum = s.sum.Sub(s.window[0])
+		s.window = s.window[1:]
+	}
+	if len(s.window) < s.period {
+		return decimal.Zero, false
+	}
+	return s.sum.Div(decimal.NewFromInt(int64(s.period))), true
+}
+
+// digits kept by each ema step, unrounded products grow by the digits of k every update
+const emaPrecision = 16
+
+// exponential moving average, seeded with the sma of the first period values
+type EMA struct {
+	period int
+	k      decimal.Decimal
+	seed   *SMA
+	value  decimal.Decimal
+	ready  bool
+}
+
+func NewEMA(period int) (*EMA, error) {
+	if err := checkPeriod("ema", period); err != nil {
+		return nil, err
+	}
+	seed, err := NewSMA(period)
+	if err != nil {
+		return nil, err
+	}
+	return &EMA{
+		period: period,
+		k:      decimal.NewFromInt(2).Div(decimal.NewFromInt(int64(period + 1))),
+		seed:   seed,
+	}, nil
+}
+
+// false until period values are in
+func (e *EMA) Update(v decimal.Decimal) (decimal.Decimal, bool) {
+	if !e.ready {
+		sma, ok := e.seed.Update(v)
+		if !ok {
+			return decimal.Zero, false
+		}
+		e.value = sma
+		e.ready = true
+		return e.value, true
+	}
+	e.value = v.Sub(e.value).Mul(e.k).Add(e.value).Round(emaPrecision)
+	return e.value, true
+}
+
+// batch forms keep the timestamps of the points they were computed on, warm-up points are left out
+
+func SMASeries(points []coingeckoapi.SeriesPoint, period int) ([]coingeckoapi.SeriesPoint, error) {
+	s, err := NewSMA(period)
+	if err != nil {
+		return nil, err
+	}
+	var out []coingeckoapi.SeriesPoint
+	for _, p := range points {
+		if v, ok := s.Update(p.Value); ok {
+			out = append(out, coingeckoapi.SeriesPoint{Time: p.Time, Value: v})
+		}
+	}
+	return out, nil
+}
+
+func EMASeries(points []coingeckoapi.SeriesPoint, period int) ([]coingeckoapi.SeriesPoint, error) {
+	e, err := NewEMA(period)
+	if err != nil {
+		return nil, err
+	}
+	var out []coingeckoapi.SeriesPoint
+	for _, p := range points {
+		if v, ok := e.Update(p.Value); ok {
+			out = append(out, coingeckoapi.SeriesPoint{Time: p.Time, Value: v})
+		}
+	}
+	return out, nil
+}
+
+func checkPeriod(name string, period int) error {
+	if period <= 0 {
+		return fmt.Errorf("%s period must be positive, got %d", name, period)
+	}
+	return nil
+}
+
+// close prices of candles as a series, for the price based indicators
+func Closes(candles []coingeckoapi.Candle) []coingeckoapi.SeriesPoint {
+	out := make([]coingeckoapi.SeriesPoint, len(candles))
+	for i, c := range candles {
+		out[i] = coingeckoapi.SeriesPoint{Time: c.Time, Value: c.Close}
+	}
+	return out
+}
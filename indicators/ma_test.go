@@ -0,0 +1,62 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestSMARamp(t *testing.T) {
+	var want []float64
+	for i := 9; i < 30; i++ {
+		want = append(want, float64(i)-4.5)
+	}
+	got, err := SMASeries(points(ramp(30)), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSeries(t, "sma", got, want, 12, start.AddDate(0, 0, 9))
+}
+
+func TestEMARamp(t *testing.T) {
+	// seeded at the sma, which already trails by 4.5, so every step keeps that lag
+	var want []float64
+	for i := 9; i < 30; i++ {
+		want = append(want, float64(i)-4.5)
+	}
+	got, err := EMASeries(points(ramp(30)), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSeries(t, "ema", got, want, 12, start.AddDate(0, 0, 9))
+}
+
+func TestEMAStep(t *testing.T) {
+	// seeded at 0 on zeros, after j ones the ema is 1 - (1-k)^j
+	values := make([]float64, 10, 30)
+	for j := 0; j < 20; j++ {
+		values = append(values, 1)
+	}
+	k := 2.0 / 11
+	var want []float64
+	for j := 0; j <= 20; j++ {
+		want = append(want, 1-math.Pow(1-k, float64(j)))
+	}
+	got, err := EMASeries(points(values), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSeries(t, "ema", got, want, 12, start.AddDate(0, 0, 9))
+}
+
+func TestEMALongSeriesStaysBounded(t *testing.T) {
+	e, _ := NewEMA(10)
+	var v decimal.Decimal
+	for i := 0; i < 5000; i++ {
+		v, _ = e.Update(decimal.NewFromFloat(float64(i%17) + 0.37))
+	}
+	if n := len(v.String()); n > 2*emaPrecision {
+		t.Errorf("ema after 5000 updates has %d characters: %s", n, v)
+	}
+}
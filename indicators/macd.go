@@ -0,0 +1,74 @@
+package indicators
+
+import (
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+type MACDValue struct {
+	MACD      decimal.Decimal
+	Signal    decimal.Decimal
+	Histogram decimal.Decimal
+}
+
+type MACDPoint struct {
+	Time time.Time
+	MACDValue
+}
+
+// fast ema - slow ema, with an ema of that as signal, usually 12, 26, 9
+type MACD struct {
+	fast   *EMA
+	slow   *EMA
+	signal *EMA
+}
+
+func NewMACD(fast, slow, signal int) (*MACD, error) {
+	m := &MACD{}
+	var err error
+	if m.fast, err = NewEMA(fast); err != nil {
+		return nil, err
+	}
+	if m.slow, err = NewEMA(slow); err != nil {
+		return nil, err
+	}
+	if m.signal, err = NewEMA(signal); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// false until slow + signal - 1 values are in
+func (m *MACD) Update(v decimal.Decimal) (MACDValue, bool) {
+	fast, ok1 := m.fast.Update(v)
+	slow, ok2 := m.slow.Update(v)
+	if !ok1 || !ok2 {
+		return MACDValue{}, false
+	}
+	line := fast.Sub(slow)
+	signal, ok := m.signal.Update(line)
+	if !ok {
+		return MACDValue{}, false
+	}
+	return MACDValue{
+		MACD:      line,
+		Signal:    signal,
+		Histogram: line.Sub(signal),
+	}, true
+}
+
+func MACDSeries(points []coingeckoapi.SeriesPoint, fast, slow, signal int) ([]MACDPoint, error) {
+	m, err := NewMACD(fast, slow, signal)
+	if err != nil {
+		return nil, err
+	}
+	var out []MACDPoint
+	for _, p := range points {
+		if v, ok := m.Update(p.Value); ok {
+			out = append(out, MACDPoint{Time: p.Time, MACDValue: v})
+		}
+	}
+	return out, nil
+}
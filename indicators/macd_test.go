@@ -0,0 +1,40 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMACDRamp(t *testing.T) {
+	// the 12 and 26 emas of a ramp trail by 5.5 and 12.5, so the line is a flat 7
+	got, err := MACDSeries(points(ramp(60)), 12, 26, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// first value needs slow + signal - 1 closes
+	if want := 60 - (26 + 9 - 2); len(got) != want {
+		t.Fatalf("got %d points, want %d", len(got), want)
+	}
+	if first := start.AddDate(0, 0, 26+9-2); !got[0].Time.Equal(first) {
+		t.Errorf("first point at %v, want %v", got[0].Time, first)
+	}
+	for i, g := range got {
+		if !near(g.MACD, 7, 12) || !near(g.Signal, 7, 12) || !near(g.Histogram, 0, 12) {
+			t.Errorf("macd[%d] = %s %s %s, want 7 7 0", i, g.MACD, g.Signal, g.Histogram)
+		}
+	}
+}
+
+func TestMACDLongSeriesStaysBounded(t *testing.T) {
+	m, _ := NewMACD(12, 26, 9)
+	var v MACDValue
+	for i := 0; i < 5000; i++ {
+		v, _ = m.Update(decimal.NewFromFloat(float64(i%23) + 0.61))
+	}
+	for _, d := range []decimal.Decimal{v.MACD, v.Signal, v.Histogram} {
+		if n := len(d.String()); n > 2*emaPrecision {
+			t.Errorf("macd after 5000 updates has %d characters: %s", n, d)
+		}
+	}
+}
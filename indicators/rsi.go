@@ -0,0 +1,77 @@
+package indicators
+
+import (
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// wilder's relative strength index, 0 - 100
+type RSI struct {
+	period  int
+	n       decimal.Decimal
+	prev    decimal.Decimal
+	count   int
+	avgGain decimal.Decimal
+	avgLoss decimal.Decimal
+}
+
+func NewRSI(period int) (*RSI, error) {
+	if err := checkPeriod("rsi", period); err != nil {
+		return nil, err
+	}
+	return &RSI{
+		period: period,
+		n:      decimal.NewFromInt(int64(period)),
+	}, nil
+}
+
+// false until period changes, period + 1 values, are in
+func (r *RSI) Update(v decimal.Decimal) (decimal.Decimal, bool) {
+	r.count++
+	if r.count == 1 {
+		r.prev = v
+		return decimal.Zero, false
+	}
+	change := v.Sub(r.prev)
+	r.prev = v
+	gain, loss := decimal.Zero, decimal.Zero
+	if change.IsPositive() {
+		gain = change
+	} else {
+		loss = change.Neg()
+	}
+	switch {
+	case r.count <= r.period:
+		// first average is a plain mean
+		r.avgGain = r.avgGain.Add(gain)
+		r.avgLoss = r.avgLoss.Add(loss)
+		return decimal.Zero, false
+	case r.count == r.period+1:
+		r.avgGain = r.avgGain.Add(gain).Div(r.n)
+		r.avgLoss = r.avgLoss.Add(loss).Div(r.n)
+	default:
+		r.avgGain = r.avgGain.Mul(r.n.Sub(decimal.NewFromInt(1))).Add(gain).Div(r.n)
+		r.avgLoss = r.avgLoss.Mul(r.n.Sub(decimal.NewFromInt(1))).Add(loss).Div(r.n)
+	}
+	if r.avgLoss.IsZero() {
+		return hundred, true
+	}
+	rs := r.avgGain.Div(r.avgLoss)
+	return hundred.Sub(hundred.Div(rs.Add(decimal.NewFromInt(1)))), true
+}
+
+func RSISeries(points []coingeckoapi.SeriesPoint, period int) ([]coingeckoapi.SeriesPoint, error) {
+	r, err := NewRSI(period)
+	if err != nil {
+		return nil, err
+	}
+	var out []coingeckoapi.SeriesPoint
+	for _, p := range points {
+		if v, ok := r.Update(p.Value); ok {
+			out = append(out, coingeckoapi.SeriesPoint{Time: p.Time, Value: v})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,25 @@
+package indicators
+
+import "testing"
+
+func TestRSIGolden(t *testing.T) {
+	want := []float64{
+		70.46, 66.25, 66.48, 69.35, 66.29, 57.92, 62.88, 63.21, 56.01, 62.34,
+		54.67, 50.39, 40.02, 41.49, 41.90, 45.50, 37.32, 33.09, 37.79,
+	}
+	got, err := RSISeries(points(rsiCloses), 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSeries(t, "rsi", got, want, 2, start.AddDate(0, 0, 14))
+}
+
+func TestRSIWithoutLosses(t *testing.T) {
+	got, err := RSISeries(points([]float64{1, 2, 3, 4}), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !got[0].Value.Equal(hundred) {
+		t.Errorf("got %v, want a single 100", got)
+	}
+}
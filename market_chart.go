@@ -0,0 +1,84 @@
+package coingeckoapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// interval options for MarketChart, empty for coingecko's auto granularity
+const (
+	ChartIntervalDaily  = "daily"
+	ChartIntervalHourly = "hourly"
+	// paid plan only
+	ChartInterval5m = "5m"
+)
+
+// values are in the quote currency
+type MarketChartResponse struct {
+	Prices       []SeriesPoint `json:"prices"`
+	MarketCaps   []SeriesPoint `json:"market_caps"`
+	TotalVolumes []SeriesPoint `json:"total_volumes"`
+}
+
+// coinID is from coins/list endpoint, quoteCurrency ex => usd
+// days ex => 1, 14, 90, max
+// granularity is 5 minutes for 1 day, hourly up to 90 days, daily above
+func (b *Client) MarketChart(coinID, quoteCurrency, days, interval string) (*MarketChartResponse, error) {
+	type opt struct {
+		Currency string `url:"vs_currency"`
+		Days     string `url:"days"`
+		Interval string `url:"interval,omitempty"`
+	}
+	input := opt{
+		Currency: strings.ToLower(quoteCurrency),
+		Days:     days,
+		Interval: interval,
+	}
+	url := fmt.Sprintf("coins/%s/market_chart", coinID)
+	res, err := b.do("spot", http.MethodGet, url, input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := MarketChartResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// same granularity rules as MarketChart, counted on to - from
+// the 5 minute granularity only applies to the last day, older 1 day ranges come back hourly
+// interval ex => ChartInterval5m, empty for the auto granularity
+func (b *Client) MarketChartRange(coinID, quoteCurrency string, from, to time.Time, interval string) (*MarketChartResponse, error) {
+	return b.MarketChartRangeContext(context.Background(), coinID, quoteCurrency, from, to, interval)
+}
+
+func (b *Client) MarketChartRangeContext(ctx context.Context, coinID, quoteCurrency string, from, to time.Time, interval string) (*MarketChartResponse, error) {
+	type opt struct {
+		Currency string `url:"vs_currency"`
+		From     int64  `url:"from"`
+		To       int64  `url:"to"`
+		Interval string `url:"interval,omitempty"`
+	}
+	input := opt{
+		Currency: strings.ToLower(quoteCurrency),
+		From:     from.Unix(),
+		To:       to.Unix(),
+		Interval: interval,
+	}
+	url := fmt.Sprintf("coins/%s/market_chart/range", coinID)
+	res, err := b.doContext(ctx, "spot", http.MethodGet, url, input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := MarketChartResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
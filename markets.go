@@ -0,0 +1,90 @@
+package coingeckoapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// order options for CoinMarkets
+const (
+	MarketOrderMarketCapDesc = "market_cap_desc"
+	MarketOrderMarketCapAsc  = "market_cap_asc"
+	MarketOrderVolumeDesc    = "volume_desc"
+	MarketOrderVolumeAsc     = "volume_asc"
+	MarketOrderIDDesc        = "id_desc"
+	MarketOrderIDAsc         = "id_asc"
+)
+
+type CoinMarketsOptions struct {
+	// comma-separated if filtering more than 1
+	IDs string `url:"ids,omitempty"`
+	// category id from coins/categories/list endpoint
+	Category string `url:"category,omitempty"`
+	// order ex => MarketOrderMarketCapDesc
+	Order string `url:"order,omitempty"`
+	// max 250
+	PerPage   int  `url:"per_page,omitempty"`
+	Page      int  `url:"page,omitempty"`
+	Sparkline bool `url:"sparkline,omitempty"`
+	// comma-separated, ex => 1h,24h,7d
+	PriceChangePercentage string `url:"price_change_percentage,omitempty"`
+}
+
+// rank, fdv and supplies are null upstream for coins without one
+type CoinMarketResponse struct {
+	ID                           string              `json:"id"`
+	Symbol                       string              `json:"symbol"`
+	Name                         string              `json:"name"`
+	Image                        string              `json:"image"`
+	CurrentPrice                 decimal.Decimal     `json:"current_price"`
+	MarketCap                    decimal.Decimal     `json:"market_cap"`
+	MarketCapRank                *int                `json:"market_cap_rank"`
+	FullyDilutedValuation        decimal.NullDecimal `json:"fully_diluted_valuation"`
+	TotalVolume                  decimal.Decimal     `json:"total_volume"`
+	High24h                      decimal.Decimal     `json:"high_24h"`
+	Low24h                       decimal.Decimal     `json:"low_24h"`
+	PriceChange24h               decimal.Decimal     `json:"price_change_24h"`
+	PriceChangePercentage24h     decimal.Decimal     `json:"price_change_percentage_24h"`
+	MarketCapChange24h           decimal.Decimal     `json:"market_cap_change_24h"`
+	MarketCapChangePercentage24h decimal.Decimal     `json:"market_cap_change_percentage_24h"`
+	CirculatingSupply            decimal.Decimal     `json:"circulating_supply"`
+	TotalSupply                  decimal.NullDecimal `json:"total_supply"`
+	MaxSupply                    decimal.NullDecimal `json:"max_supply"`
+	Ath                          decimal.Decimal     `json:"ath"`
+	AthChangePercentage          decimal.Decimal     `json:"ath_change_percentage"`
+	AthDate                      time.Time           `json:"ath_date"`
+	Atl                          decimal.Decimal     `json:"atl"`
+	AtlChangePercentage          decimal.Decimal     `json:"atl_change_percentage"`
+	AtlDate                      time.Time           `json:"atl_date"`
+	LastUpdated                  time.Time           `json:"last_updated"`
+	SparklineIn7d                struct {
+		Price []decimal.Decimal `json:"price"`
+	} `json:"sparkline_in_7d"`
+}
+
+// quoteCurrency ex => usd, opt can be nil
+func (b *Client) CoinMarkets(quoteCurrency string, opt *CoinMarketsOptions) ([]CoinMarketResponse, error) {
+	type query struct {
+		Currency string `url:"vs_currency"`
+		CoinMarketsOptions
+	}
+	input := query{
+		Currency: strings.ToLower(quoteCurrency),
+	}
+	if opt != nil {
+		input.CoinMarketsOptions = *opt
+	}
+	res, err := b.do("spot", http.MethodGet, "coins/markets", input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []CoinMarketResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,139 @@
+package coingeckoapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+)
+
+// order options for NftsList
+const (
+	NftOrderH24VolumeNativeAsc   = "h24_volume_native_asc"
+	NftOrderH24VolumeNativeDesc  = "h24_volume_native_desc"
+	NftOrderFloorPriceNativeAsc  = "floor_price_native_asc"
+	NftOrderFloorPriceNativeDesc = "floor_price_native_desc"
+	NftOrderMarketCapNativeAsc   = "market_cap_native_asc"
+	NftOrderMarketCapNativeDesc  = "market_cap_native_desc"
+	NftOrderMarketCapUsdAsc      = "market_cap_usd_asc"
+	NftOrderMarketCapUsdDesc     = "market_cap_usd_desc"
+)
+
+const nftsMaxPerPage = 250
+
+type NftListResponse struct {
+	ID              string `json:"id"`
+	ContractAddress string `json:"contract_address"`
+	Name            string `json:"name"`
+	AssetPlatformID string `json:"asset_platform_id"`
+	Symbol          string `json:"symbol"`
+}
+
+// native_currency and usd values
+type NftValue struct {
+	NativeCurrency decimal.Decimal `json:"native_currency"`
+	Usd            decimal.Decimal `json:"usd"`
+}
+
+type NftResponse struct {
+	ID              string `json:"id"`
+	ContractAddress string `json:"contract_address"`
+	AssetPlatformID string `json:"asset_platform_id"`
+	Name            string `json:"name"`
+	Symbol          string `json:"symbol"`
+	Image           struct {
+		Small string `json:"small"`
+	} `json:"image"`
+	Description                                string          `json:"description"`
+	NativeCurrency                             string          `json:"native_currency"`
+	NativeCurrencySymbol                       string          `json:"native_currency_symbol"`
+	FloorPrice                                 NftValue        `json:"floor_price"`
+	MarketCap                                  NftValue        `json:"market_cap"`
+	Volume24h                                  NftValue        `json:"volume_24h"`
+	FloorPriceInUsd24hPercentageChange         decimal.Decimal `json:"floor_price_in_usd_24h_percentage_change"`
+	FloorPrice24hPercentageChange              NftValue        `json:"floor_price_24h_percentage_change"`
+	MarketCap24hPercentageChange               NftValue        `json:"market_cap_24h_percentage_change"`
+	Volume24hPercentageChange                  NftValue        `json:"volume_24h_percentage_change"`
+	NumberOfUniqueAddresses                    int64           `json:"number_of_unique_addresses"`
+	NumberOfUniqueAddresses24hPercentageChange decimal.Decimal `json:"number_of_unique_addresses_24h_percentage_change"`
+	VolumeInUsd24hPercentageChange             decimal.Decimal `json:"volume_in_usd_24h_percentage_change"`
+	TotalSupply                                decimal.Decimal `json:"total_supply"`
+	OneDaySales                                decimal.Decimal `json:"one_day_sales"`
+	OneDaySales24hPercentageChange             decimal.Decimal `json:"one_day_sales_24h_percentage_change"`
+	OneDayAverageSalePrice                     decimal.Decimal `json:"one_day_average_sale_price"`
+	Links                                      struct {
+		Homepage string `json:"homepage"`
+		Twitter  string `json:"twitter"`
+		Discord  string `json:"discord"`
+	} `json:"links"`
+}
+
+// order ex => NftOrderMarketCapUsdDesc, empty for default
+// perPage max is 250, page starts from 1
+func (b *Client) NftsList(order string, perPage, page int) ([]NftListResponse, error) {
+	type opt struct {
+		Order   string `url:"order,omitempty"`
+		PerPage int    `url:"per_page,omitempty"`
+		Page    int    `url:"page,omitempty"`
+	}
+	input := opt{
+		Order:   order,
+		PerPage: perPage,
+		Page:    page,
+	}
+	res, err := b.do("spot", http.MethodGet, "nfts/list", input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := []NftListResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// walks all the pages of nfts/list endpoint
+func (b *Client) AllNfts(order string) ([]NftListResponse, error) {
+	var out []NftListResponse
+	for page := 1; ; page++ {
+		result, err := b.NftsList(order, nftsMaxPerPage, page)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, result...)
+		if len(result) < nftsMaxPerPage {
+			return out, nil
+		}
+	}
+}
+
+// nftID is from nfts/list endpoint
+func (b *Client) Nft(nftID string) (*NftResponse, error) {
+	url := fmt.Sprintf("nfts/%s", nftID)
+	res, err := b.do("spot", http.MethodGet, url, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := NftResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// platformID is from asset_platforms endpoint, ex => ethereum
+func (b *Client) NftByContract(platformID, contract string) (*NftResponse, error) {
+	url := fmt.Sprintf("nfts/%s/contract/%s", platformID, contract)
+	res, err := b.do("spot", http.MethodGet, url, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := NftResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
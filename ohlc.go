@@ -0,0 +1,43 @@
+package coingeckoapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// coinID is from coins/list endpoint, quoteCurrency ex => usd
+// days ex => 1, 7, 14, 30, 90, 180, 365, max
+// candle width is 30m for 1-2 days, 4h for 3-30 days, 4d above, no volume
+func (b *Client) OHLC(coinID, quoteCurrency, days string) ([]Candle, error) {
+	type opt struct {
+		Currency string `url:"vs_currency"`
+		Days     string `url:"days"`
+	}
+	input := opt{
+		Currency: strings.ToLower(quoteCurrency),
+		Days:     days,
+	}
+	url := fmt.Sprintf("coins/%s/ohlc", coinID)
+	res, err := b.do("spot", http.MethodGet, url, input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	rows := [][]jsoniter.RawMessage{}
+	err = json.Unmarshal(res, &rows)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Candle, 0, len(rows))
+	for _, row := range rows {
+		candle, err := candleFromRow(row, time.Millisecond)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, candle)
+	}
+	return result, nil
+}
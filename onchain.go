@@ -0,0 +1,374 @@
+package coingeckoapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/shopspring/decimal"
+)
+
+// timeframe options for OnchainPoolOHLCV
+const (
+	OnchainTimeframeDay    = "day"
+	OnchainTimeframeHour   = "hour"
+	OnchainTimeframeMinute = "minute"
+)
+
+// max addresses per multi pool call
+const onchainMaxMultiAddresses = 30
+
+// one json:api resource, attributes are decoded by the caller
+type jsonapiResource struct {
+	ID         string              `json:"id"`
+	Type       string              `json:"type"`
+	Attributes jsoniter.RawMessage `json:"attributes"`
+	// data is one identifier for to-one relationships, a list for to-many
+	Relationships map[string]struct {
+		Data jsoniter.RawMessage `json:"data"`
+	} `json:"relationships"`
+}
+
+type jsonapiIdentifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// id of a to-one relationship, empty if missing
+func (r *jsonapiResource) relationID(name string) string {
+	ids := r.relationIDs(name)
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[0]
+}
+
+// ids of a relationship of either kind, nil if missing or malformed
+func (r *jsonapiResource) relationIDs(name string) []string {
+	rel, ok := r.Relationships[name]
+	if !ok {
+		return nil
+	}
+	data := strings.TrimSpace(string(rel.Data))
+	var list []jsonapiIdentifier
+	switch {
+	case data == "" || data == "null":
+		return nil
+	case strings.HasPrefix(data, "["):
+		if err := json.Unmarshal(rel.Data, &list); err != nil {
+			return nil
+		}
+	default:
+		one := jsonapiIdentifier{}
+		if err := json.Unmarshal(rel.Data, &one); err != nil {
+			return nil
+		}
+		list = append(list, one)
+	}
+	out := make([]string, 0, len(list))
+	for _, x := range list {
+		out = append(out, x.ID)
+	}
+	return out
+}
+
+// data can be a single resource or a list
+func decodeJSONAPI(res []byte) ([]jsonapiResource, error) {
+	doc := struct {
+		Data jsoniter.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(res, &doc); err != nil {
+		return nil, err
+	}
+	data := strings.TrimSpace(string(doc.Data))
+	switch {
+	case data == "" || data == "null":
+		return nil, nil
+	case strings.HasPrefix(data, "["):
+		out := []jsonapiResource{}
+		if err := json.Unmarshal(doc.Data, &out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	default:
+		one := jsonapiResource{}
+		if err := json.Unmarshal(doc.Data, &one); err != nil {
+			return nil, err
+		}
+		return []jsonapiResource{one}, nil
+	}
+}
+
+type OnchainNetwork struct {
+	ID                       string `json:"-"`
+	Name                     string `json:"name"`
+	CoingeckoAssetPlatformID string `json:"coingecko_asset_platform_id"`
+}
+
+type OnchainDex struct {
+	ID   string `json:"-"`
+	Name string `json:"name"`
+}
+
+// m5, h1, h6, h24 windows
+type OnchainWindows struct {
+	M5  decimal.Decimal `json:"m5"`
+	H1  decimal.Decimal `json:"h1"`
+	H6  decimal.Decimal `json:"h6"`
+	H24 decimal.Decimal `json:"h24"`
+}
+
+type OnchainTransactions struct {
+	Buys    int `json:"buys"`
+	Sells   int `json:"sells"`
+	Buyers  int `json:"buyers"`
+	Sellers int `json:"sellers"`
+}
+
+type OnchainPool struct {
+	// network prefixed, ex => eth_0x88e6...
+	ID                            string          `json:"-"`
+	Address                       string          `json:"address"`
+	Name                          string          `json:"name"`
+	BaseTokenPriceUsd             decimal.Decimal `json:"base_token_price_usd"`
+	BaseTokenPriceNativeCurrency  decimal.Decimal `json:"base_token_price_native_currency"`
+	QuoteTokenPriceUsd            decimal.Decimal `json:"quote_token_price_usd"`
+	QuoteTokenPriceNativeCurrency decimal.Decimal `json:"quote_token_price_native_currency"`
+	PoolCreatedAt                 time.Time       `json:"pool_created_at"`
+	FdvUsd                        decimal.Decimal `json:"fdv_usd"`
+	MarketCapUsd                  decimal.Decimal `json:"market_cap_usd"`
+	PriceChangePercentage         OnchainWindows  `json:"price_change_percentage"`
+	Transactions                  struct {
+		M5  OnchainTransactions `json:"m5"`
+		H1  OnchainTransactions `json:"h1"`
+		H24 OnchainTransactions `json:"h24"`
+	} `json:"transactions"`
+	VolumeUsd    OnchainWindows  `json:"volume_usd"`
+	ReserveInUsd decimal.Decimal `json:"reserve_in_usd"`
+	// from relationships, network prefixed
+	BaseTokenID  string `json:"-"`
+	QuoteTokenID string `json:"-"`
+	DexID        string `json:"-"`
+}
+
+type OnchainToken struct {
+	ID                string          `json:"-"`
+	Address           string          `json:"address"`
+	Name              string          `json:"name"`
+	Symbol            string          `json:"symbol"`
+	Decimals          int             `json:"decimals"`
+	ImageURL          string          `json:"image_url"`
+	CoingeckoCoinID   string          `json:"coingecko_coin_id"`
+	TotalSupply       decimal.Decimal `json:"total_supply"`
+	PriceUsd          decimal.Decimal `json:"price_usd"`
+	FdvUsd            decimal.Decimal `json:"fdv_usd"`
+	TotalReserveInUsd decimal.Decimal `json:"total_reserve_in_usd"`
+	VolumeUsd         struct {
+		H24 decimal.Decimal `json:"h24"`
+	} `json:"volume_usd"`
+	MarketCapUsd decimal.Decimal `json:"market_cap_usd"`
+	// from relationships, network prefixed
+	TopPoolIDs []string `json:"-"`
+}
+
+type OnchainOHLCVOptions struct {
+	// 1, 5, 15 for minute, 1, 4, 12 for hour, 1 for day, 0 for default
+	Aggregate int `url:"aggregate,omitempty"`
+	// zero for now
+	BeforeTimestamp int64 `url:"before_timestamp,omitempty"`
+	// max 1000, 0 for default
+	Limit int `url:"limit,omitempty"`
+	// usd or token, empty for default
+	Currency string `url:"currency,omitempty"`
+	// base or quote, empty for default
+	Token string `url:"token,omitempty"`
+}
+
+func (b *Client) onchainResources(path string, data interface{}) ([]jsonapiResource, error) {
+	res, err := b.do("onchain", http.MethodGet, path, data, false, false)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSONAPI(res)
+}
+
+func pageOpt(page int) interface{} {
+	type opt struct {
+		Page int `url:"page,omitempty"`
+	}
+	return opt{
+		Page: page,
+	}
+}
+
+func onchainPools(resources []jsonapiResource) ([]OnchainPool, error) {
+	out := make([]OnchainPool, 0, len(resources))
+	for i := range resources {
+		r := &resources[i]
+		pool := OnchainPool{}
+		if err := json.Unmarshal(r.Attributes, &pool); err != nil {
+			return nil, err
+		}
+		pool.ID = r.ID
+		pool.BaseTokenID = r.relationID("base_token")
+		pool.QuoteTokenID = r.relationID("quote_token")
+		pool.DexID = r.relationID("dex")
+		out = append(out, pool)
+	}
+	return out, nil
+}
+
+// page 0 for first page
+func (b *Client) OnchainNetworks(page int) ([]OnchainNetwork, error) {
+	resources, err := b.onchainResources("networks", pageOpt(page))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]OnchainNetwork, 0, len(resources))
+	for _, r := range resources {
+		network := OnchainNetwork{}
+		if err := json.Unmarshal(r.Attributes, &network); err != nil {
+			return nil, err
+		}
+		network.ID = r.ID
+		out = append(out, network)
+	}
+	return out, nil
+}
+
+// network is from OnchainNetworks, ex => eth
+func (b *Client) OnchainDexes(network string, page int) ([]OnchainDex, error) {
+	url := fmt.Sprintf("networks/%s/dexes", network)
+	resources, err := b.onchainResources(url, pageOpt(page))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]OnchainDex, 0, len(resources))
+	for _, r := range resources {
+		dex := OnchainDex{}
+		if err := json.Unmarshal(r.Attributes, &dex); err != nil {
+			return nil, err
+		}
+		dex.ID = r.ID
+		out = append(out, dex)
+	}
+	return out, nil
+}
+
+func (b *Client) OnchainPool(network, poolAddress string) (*OnchainPool, error) {
+	url := fmt.Sprintf("networks/%s/pools/%s", network, poolAddress)
+	resources, err := b.onchainResources(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := onchainPools(resources)
+	if err != nil {
+		return nil, err
+	}
+	if len(pools) == 0 {
+		return nil, errors.New(fmt.Sprintf("pool not found: %s", poolAddress))
+	}
+	return &pools[0], nil
+}
+
+// max 30 addresses per call
+func (b *Client) OnchainPools(network string, poolAddresses []string) ([]OnchainPool, error) {
+	if len(poolAddresses) > onchainMaxMultiAddresses {
+		return nil, errors.New(fmt.Sprintf("too many pool addresses: %d, max %d", len(poolAddresses), onchainMaxMultiAddresses))
+	}
+	url := fmt.Sprintf("networks/%s/pools/multi/%s", network, strings.Join(poolAddresses, ","))
+	resources, err := b.onchainResources(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return onchainPools(resources)
+}
+
+// top pools by volume on network
+func (b *Client) OnchainTopPools(network string, page int) ([]OnchainPool, error) {
+	url := fmt.Sprintf("networks/%s/pools", network)
+	resources, err := b.onchainResources(url, pageOpt(page))
+	if err != nil {
+		return nil, err
+	}
+	return onchainPools(resources)
+}
+
+// network empty for all networks
+func (b *Client) OnchainTrendingPools(network string, page int) ([]OnchainPool, error) {
+	url := "networks/trending_pools"
+	if network != "" {
+		url = fmt.Sprintf("networks/%s/trending_pools", network)
+	}
+	resources, err := b.onchainResources(url, pageOpt(page))
+	if err != nil {
+		return nil, err
+	}
+	return onchainPools(resources)
+}
+
+// network empty for all networks
+func (b *Client) OnchainNewPools(network string, page int) ([]OnchainPool, error) {
+	url := "networks/new_pools"
+	if network != "" {
+		url = fmt.Sprintf("networks/%s/new_pools", network)
+	}
+	resources, err := b.onchainResources(url, pageOpt(page))
+	if err != nil {
+		return nil, err
+	}
+	return onchainPools(resources)
+}
+
+func (b *Client) OnchainToken(network, tokenAddress string) (*OnchainToken, error) {
+	url := fmt.Sprintf("networks/%s/tokens/%s", network, tokenAddress)
+	resources, err := b.onchainResources(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) == 0 {
+		return nil, errors.New(fmt.Sprintf("token not found: %s", tokenAddress))
+	}
+	return tokenFromResource(&resources[0])
+}
+
+func tokenFromResource(r *jsonapiResource) (*OnchainToken, error) {
+	token := OnchainToken{}
+	if err := json.Unmarshal(r.Attributes, &token); err != nil {
+		return nil, err
+	}
+	token.ID = r.ID
+	token.TopPoolIDs = r.relationIDs("top_pools")
+	return &token, nil
+}
+
+// timeframe ex => OnchainTimeframeHour, opt can be nil
+// candles come newest first
+func (b *Client) OnchainPoolOHLCV(network, poolAddress, timeframe string, opt *OnchainOHLCVOptions) ([]Candle, error) {
+	url := fmt.Sprintf("networks/%s/pools/%s/ohlcv/%s", network, poolAddress, timeframe)
+	resources, err := b.onchainResources(url, opt)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) == 0 {
+		return nil, nil
+	}
+	attrs := struct {
+		OhlcvList [][]jsoniter.RawMessage `json:"ohlcv_list"`
+	}{}
+	if err := json.Unmarshal(resources[0].Attributes, &attrs); err != nil {
+		return nil, err
+	}
+	out := make([]Candle, 0, len(attrs.OhlcvList))
+	for _, row := range attrs.OhlcvList {
+		candle, err := candleFromRow(row, time.Second)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, candle)
+	}
+	return out, nil
+}
@@ -0,0 +1,78 @@
+package coingeckoapi
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// fixtures follow the documented geckoterminal responses, api version 20230302
+
+func readResources(t *testing.T, name string) []jsonapiResource {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resources, err := decodeJSONAPI(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("got %d resources, want 1", len(resources))
+	}
+	return resources
+}
+
+func TestOnchainTokenToManyRelationship(t *testing.T) {
+	resources := readResources(t, "onchain_token.json")
+	token, err := tokenFromResource(&resources[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.ID != "eth_0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2" || token.Symbol != "WETH" || token.Decimals != 18 {
+		t.Errorf("got %s %s %d", token.ID, token.Symbol, token.Decimals)
+	}
+	if !token.PriceUsd.Equal(decimal.RequireFromString("3639.78228891614")) {
+		t.Errorf("price_usd = %s", token.PriceUsd)
+	}
+	if !token.MarketCapUsd.IsZero() {
+		t.Errorf("null market_cap_usd = %s, want 0", token.MarketCapUsd)
+	}
+	want := []string{
+		"eth_0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640",
+		"eth_0x11b815efb8f581194ae79006d24e0d814b7697f6",
+		"eth_0x4e68ccd3e89f51c3074ca5072bbac773960dfa36",
+	}
+	if !reflect.DeepEqual(token.TopPoolIDs, want) {
+		t.Errorf("top pools = %v, want %v", token.TopPoolIDs, want)
+	}
+	if id := resources[0].relationID("top_pools"); id != want[0] {
+		t.Errorf("relationID of a to-many = %q, want the first id", id)
+	}
+}
+
+func TestOnchainPoolToOneRelationships(t *testing.T) {
+	pools, err := onchainPools(readResources(t, "onchain_pool.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := pools[0]
+	if p.BaseTokenID != "eth_0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2" ||
+		p.QuoteTokenID != "eth_0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48" ||
+		p.DexID != "uniswap_v3" {
+		t.Errorf("relationships = %s %s %s", p.BaseTokenID, p.QuoteTokenID, p.DexID)
+	}
+	if !p.PoolCreatedAt.Equal(time.Date(2021, 12, 29, 12, 35, 14, 0, time.UTC)) {
+		t.Errorf("pool_created_at = %v", p.PoolCreatedAt)
+	}
+	if p.Transactions.H24.Sells != 3847 || !p.VolumeUsd.H24.Equal(decimal.RequireFromString("536545444.904535")) {
+		t.Errorf("h24 = %d sells, %s volume", p.Transactions.H24.Sells, p.VolumeUsd.H24)
+	}
+	if ids := (&jsonapiResource{}).relationIDs("missing"); ids != nil {
+		t.Errorf("missing relationship = %v, want nil", ids)
+	}
+}
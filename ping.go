@@ -0,0 +1,93 @@
+package coingeckoapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type PingResponse struct {
+	GeckoSays string `json:"gecko_says"`
+	// round trip of the ping call
+	Latency time.Duration `json:"-"`
+}
+
+// paid plans only
+type APIUsageResponse struct {
+	Plan                         string `json:"plan"`
+	RateLimitRequestPerMinute    int    `json:"rate_limit_request_per_minute"`
+	MonthlyCallCredit            int64  `json:"monthly_call_credit"`
+	CurrentTotalMonthlyCalls     int64  `json:"current_total_monthly_calls"`
+	CurrentRemainingMonthlyCalls int64  `json:"current_remaining_monthly_calls"`
+}
+
+// ready to be serialized by a /healthz handler
+type Health struct {
+	Healthy   bool   `json:"healthy"`
+	GeckoSays string `json:"gecko_says,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+	PingError string `json:"ping_error,omitempty"`
+	// only checked on paid plans
+	Key      *APIUsageResponse `json:"key,omitempty"`
+	KeyError string            `json:"key_error,omitempty"`
+	// upstream calls within the last WindowSeconds
+	Recent        RequestCounts `json:"recent"`
+	WindowSeconds int64         `json:"window_seconds"`
+	CheckedAt     time.Time     `json:"checked_at"`
+}
+
+func (b *Client) Ping(ctx context.Context) (*PingResponse, error) {
+	start := time.Now()
+	res, err := b.doContext(ctx, "spot", http.MethodGet, "ping", nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := PingResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	result.Latency = time.Since(start)
+	return &result, nil
+}
+
+// key usage and limits, needs a pro plan key
+func (b *Client) APIUsage(ctx context.Context) (*APIUsageResponse, error) {
+	res, err := b.doContext(ctx, "spot", http.MethodGet, "key", nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := APIUsageResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// healthy when ping works and, on paid plans, the key is accepted
+func (b *Client) HealthCheck(ctx context.Context) *Health {
+	out := Health{
+		CheckedAt:     time.Now(),
+		WindowSeconds: int64(statsWindow / time.Second),
+	}
+	ping, err := b.Ping(ctx)
+	if err != nil {
+		out.PingError = err.Error()
+	} else {
+		out.GeckoSays = ping.GeckoSays
+		out.LatencyMs = ping.Latency.Milliseconds()
+		out.Healthy = true
+	}
+	if b.apiKey != "" && b.plan == PlanPro {
+		key, err := b.APIUsage(ctx)
+		if err != nil {
+			out.KeyError = err.Error()
+			out.Healthy = false
+		} else {
+			out.Key = key
+		}
+	}
+	out.Recent = b.RecentRequests()
+	return &out
+}
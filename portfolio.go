@@ -0,0 +1,146 @@
+package coingeckoapi
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// either CoinID, or PlatformID with Contract
+type Holding struct {
+	CoinID     string
+	PlatformID string
+	Contract   string
+	Quantity   decimal.Decimal
+}
+
+func (h Holding) isContract() bool {
+	return h.CoinID == "" && h.Contract != ""
+}
+
+// ids, platforms and contracts as coingecko keys them
+func (h Holding) normalize() Holding {
+	h.CoinID = strings.ToLower(strings.TrimSpace(h.CoinID))
+	h.PlatformID = strings.ToLower(strings.TrimSpace(h.PlatformID))
+	h.Contract = strings.ToLower(strings.TrimSpace(h.Contract))
+	return h
+}
+
+type AssetValue struct {
+	Holding
+	Price decimal.Decimal
+	Value decimal.Decimal
+	// share of the total value, 0 - 1
+	Weight decimal.Decimal
+	// percent, as reported by coingecko
+	Change24h decimal.Decimal
+	// value gained or lost over 24h
+	ValueChange24h decimal.Decimal
+}
+
+type Valuation struct {
+	QuoteCurrency string
+	Total         decimal.Decimal
+	// percent of the total value 24h ago
+	Change24h      decimal.Decimal
+	ValueChange24h decimal.Decimal
+	Assets         []AssetValue
+	// holdings without a price or without a platform for their contract, left out of Total
+	Missing []Holding
+}
+
+type Portfolio struct {
+	client   *Client
+	Holdings []Holding
+}
+
+func (b *Client) NewPortfolio(holdings ...Holding) *Portfolio {
+	return &Portfolio{
+		client:   b,
+		Holdings: holdings,
+	}
+}
+
+// quoteCurrency ex => usd
+// one simple/price call per 100 coin ids, one token_price call per platform and 30 contracts
+func (p *Portfolio) Value(ctx context.Context, quoteCurrency string) (*Valuation, error) {
+	vs := strings.ToLower(quoteCurrency)
+	opt := &SimplePriceOptions{Include24hChange: true}
+
+	idSet := make(map[string]struct{})
+	contractSets := make(map[string]map[string]struct{})
+	for _, h := range p.Holdings {
+		h = h.normalize()
+		switch {
+		case h.isContract() && h.PlatformID == "":
+			// no platform to ask, reported under Missing
+		case h.isContract():
+			if contractSets[h.PlatformID] == nil {
+				contractSets[h.PlatformID] = make(map[string]struct{})
+			}
+			contractSets[h.PlatformID][h.Contract] = struct{}{}
+		case h.CoinID != "":
+			idSet[h.CoinID] = struct{}{}
+		}
+	}
+
+	coins := make(SimplePricesResponse)
+	if len(idSet) > 0 {
+		var err error
+		coins, err = p.client.SimplePricesContext(ctx, sortedKeys(idSet), []string{vs}, opt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tokens := make(map[string]SimplePricesResponse)
+	for platform, set := range contractSets {
+		prices, err := p.client.TokenPriceContext(ctx, platform, sortedKeys(set), []string{vs}, opt)
+		if err != nil {
+			return nil, err
+		}
+		tokens[platform] = prices
+	}
+
+	out := Valuation{
+		QuoteCurrency: vs,
+	}
+	hundred := decimal.NewFromInt(100)
+	for _, h := range p.Holdings {
+		var quote SimplePriceQuote
+		var found bool
+		if n := h.normalize(); n.isContract() {
+			quote, found = tokens[n.PlatformID][n.Contract]
+		} else {
+			quote, found = coins[n.CoinID]
+		}
+		price, ok := quote.Price[vs]
+		if !found || !ok {
+			out.Missing = append(out.Missing, h)
+			continue
+		}
+		asset := AssetValue{
+			Holding:   h,
+			Price:     price,
+			Value:     price.Mul(h.Quantity),
+			Change24h: quote.Change24h[vs],
+		}
+		// value 24h ago = value / (1 + change%)
+		base := hundred.Add(asset.Change24h)
+		if !base.IsZero() {
+			asset.ValueChange24h = asset.Value.Sub(asset.Value.Mul(hundred).Div(base))
+		}
+		out.Total = out.Total.Add(asset.Value)
+		out.ValueChange24h = out.ValueChange24h.Add(asset.ValueChange24h)
+		out.Assets = append(out.Assets, asset)
+	}
+	if !out.Total.IsZero() {
+		for i := range out.Assets {
+			out.Assets[i].Weight = out.Assets[i].Value.Div(out.Total)
+		}
+	}
+	if before := out.Total.Sub(out.ValueChange24h); !before.IsZero() {
+		out.Change24h = out.ValueChange24h.Div(before).Mul(hundred)
+	}
+	return &out, nil
+}
@@ -1,6 +1,7 @@
 package coingeckoapi
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -80,6 +81,33 @@ func (b *Client) PriceFromData(baseID, quoteCurrency string) (decimal.Decimal, e
 	return out, nil
 }
 
+// full coin detail with market data, no tickers, community or developer data
+// coinID is from coins/list endpoint
+func (b *Client) CoinData(coinID string) (*PriceFromDataResponse, error) {
+	type opt struct {
+		Localization  bool `url:"localization"`
+		Tickers       bool `url:"tickers"`
+		MarketData    bool `url:"market_data"`
+		CommunityData bool `url:"community_data"`
+		DeveloperData bool `url:"developer_data"`
+		SparkLine     bool `url:"sparkline"`
+	}
+	input := opt{
+		MarketData: true,
+	}
+	url := fmt.Sprintf("coins/%s", coinID)
+	res, err := b.do("spot", http.MethodGet, url, input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := PriceFromDataResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 type PriceFromDataResponse struct {
 	ID              string      `json:"id"`
 	Symbol          string      `json:"symbol"`
@@ -90,7 +118,7 @@ type PriceFromDataResponse struct {
 	} `json:"platforms"`
 	BlockTimeInMinutes float64       `json:"block_time_in_minutes"`
 	HashingAlgorithm   interface{}   `json:"hashing_algorithm"`
-	Categories         []interface{} `json:"categories"`
+	Categories         []string      `json:"categories"`
 	PublicNotice       interface{}   `json:"public_notice"`
 	AdditionalNotices  []interface{} `json:"additional_notices"`
 	Description        struct {
@@ -1178,3 +1206,175 @@ type PriceFromDataResponse struct {
 	StatusUpdates []interface{} `json:"status_updates"`
 	LastUpdated   time.Time     `json:"last_updated"`
 }
+
+type SimplePriceOptions struct {
+	IncludeMarketCap     bool `url:"include_market_cap,omitempty"`
+	Include24hVol        bool `url:"include_24hr_vol,omitempty"`
+	Include24hChange     bool `url:"include_24hr_change,omitempty"`
+	IncludeLastUpdatedAt bool `url:"include_last_updated_at,omitempty"`
+	// ex => full, or 0 - 18 decimal places
+	Precision string `url:"precision,omitempty"`
+}
+
+// quote currency => value, only the included fields are filled
+type SimplePriceQuote struct {
+	Price         map[string]decimal.Decimal `json:"price"`
+	MarketCap     map[string]decimal.Decimal `json:"market_cap,omitempty"`
+	Vol24h        map[string]decimal.Decimal `json:"vol_24h,omitempty"`
+	Change24h     map[string]decimal.Decimal `json:"change_24h,omitempty"`
+	LastUpdatedAt time.Time                  `json:"last_updated_at"`
+}
+
+// base id => quote
+type SimplePricesResponse map[string]SimplePriceQuote
+
+// ids and contracts per simple/price and simple/token_price call, keeps the url at a sane length
+const (
+	maxIDsPerCall       = 100
+	maxContractsPerCall = 30
+)
+
+// baseIDs are from coins/list endpoint, quoteCurrencies ex => usd, eur
+// one call per 100 ids, opt can be nil
+// ids unknown to coingecko are missing from the result
+func (b *Client) SimplePrices(baseIDs, quoteCurrencies []string, opt *SimplePriceOptions) (SimplePricesResponse, error) {
+	return b.SimplePricesContext(context.Background(), baseIDs, quoteCurrencies, opt)
+}
+
+func (b *Client) SimplePricesContext(ctx context.Context, baseIDs, quoteCurrencies []string, opt *SimplePriceOptions) (SimplePricesResponse, error) {
+	out := make(SimplePricesResponse, len(baseIDs))
+	for _, chunk := range chunkStrings(baseIDs, maxIDsPerCall) {
+		prices, err := b.simplePrices(ctx, chunk, quoteCurrencies, opt)
+		if err != nil {
+			return nil, err
+		}
+		for id, quote := range prices {
+			out[id] = quote
+		}
+	}
+	return out, nil
+}
+
+func (b *Client) simplePrices(ctx context.Context, baseIDs, quoteCurrencies []string, opt *SimplePriceOptions) (SimplePricesResponse, error) {
+	type query struct {
+		Base     string `url:"ids"`
+		Currency string `url:"vs_currencies"`
+		SimplePriceOptions
+	}
+	input := query{
+		Base:     strings.Join(baseIDs, ","),
+		Currency: strings.ToLower(strings.Join(quoteCurrencies, ",")),
+	}
+	if opt != nil {
+		input.SimplePriceOptions = *opt
+	}
+	res, err := b.doContext(ctx, "spot", http.MethodGet, "simple/price", input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	return parseSimplePrices(res)
+}
+
+// platformID is from asset_platforms endpoint, quoteCurrencies ex => usd
+// one call per 30 contracts, contracts are keyed lower case, opt can be nil
+func (b *Client) TokenPrice(platformID string, contracts, quoteCurrencies []string, opt *SimplePriceOptions) (SimplePricesResponse, error) {
+	return b.TokenPriceContext(context.Background(), platformID, contracts, quoteCurrencies, opt)
+}
+
+func (b *Client) TokenPriceContext(ctx context.Context, platformID string, contracts, quoteCurrencies []string, opt *SimplePriceOptions) (SimplePricesResponse, error) {
+	out := make(SimplePricesResponse, len(contracts))
+	for _, chunk := range chunkStrings(contracts, maxContractsPerCall) {
+		prices, err := b.tokenPrice(ctx, platformID, chunk, quoteCurrencies, opt)
+		if err != nil {
+			return nil, err
+		}
+		for contract, quote := range prices {
+			out[contract] = quote
+		}
+	}
+	return out, nil
+}
+
+func (b *Client) tokenPrice(ctx context.Context, platformID string, contracts, quoteCurrencies []string, opt *SimplePriceOptions) (SimplePricesResponse, error) {
+	type query struct {
+		Contracts string `url:"contract_addresses"`
+		Currency  string `url:"vs_currencies"`
+		SimplePriceOptions
+	}
+	input := query{
+		Contracts: strings.Join(contracts, ","),
+		Currency:  strings.ToLower(strings.Join(quoteCurrencies, ",")),
+	}
+	if opt != nil {
+		input.SimplePriceOptions = *opt
+	}
+	url := fmt.Sprintf("simple/token_price/%s", platformID)
+	res, err := b.doContext(ctx, "spot", http.MethodGet, url, input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result, err := parseSimplePrices(res)
+	if err != nil {
+		return nil, err
+	}
+	out := make(SimplePricesResponse, len(result))
+	for contract, quote := range result {
+		out[strings.ToLower(contract)] = quote
+	}
+	return out, nil
+}
+
+// simple/price and simple/token_price share the same shape
+func parseSimplePrices(res []byte) (SimplePricesResponse, error) {
+	raw := map[string]map[string]*decimal.Decimal{}
+	err := json.Unmarshal(res, &raw)
+	if err != nil {
+		return nil, err
+	}
+	result := make(SimplePricesResponse, len(raw))
+	for id, fields := range raw {
+		quote := SimplePriceQuote{
+			Price: make(map[string]decimal.Decimal),
+		}
+		for key, value := range fields {
+			if value == nil {
+				continue
+			}
+			switch {
+			case key == "last_updated_at":
+				quote.LastUpdatedAt = time.Unix(value.IntPart(), 0)
+			case strings.HasSuffix(key, "_market_cap"):
+				if quote.MarketCap == nil {
+					quote.MarketCap = make(map[string]decimal.Decimal)
+				}
+				quote.MarketCap[strings.TrimSuffix(key, "_market_cap")] = *value
+			case strings.HasSuffix(key, "_24h_vol"):
+				if quote.Vol24h == nil {
+					quote.Vol24h = make(map[string]decimal.Decimal)
+				}
+				quote.Vol24h[strings.TrimSuffix(key, "_24h_vol")] = *value
+			case strings.HasSuffix(key, "_24h_change"):
+				if quote.Change24h == nil {
+					quote.Change24h = make(map[string]decimal.Decimal)
+				}
+				quote.Change24h[strings.TrimSuffix(key, "_24h_change")] = *value
+			default:
+				quote.Price[key] = *value
+			}
+		}
+		result[id] = quote
+	}
+	return result, nil
+}
+
+func chunkStrings(list []string, size int) [][]string {
+	var out [][]string
+	for start := 0; start < len(list); start += size {
+		end := start + size
+		if end > len(list) {
+			end = len(list)
+		}
+		out = append(out, list[start:end])
+	}
+	return out
+}
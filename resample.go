@@ -0,0 +1,150 @@
+package coingeckoapi
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// common candle widths for ResampleOptions
+const (
+	Width5m  = 5 * time.Minute
+	Width15m = 15 * time.Minute
+	Width1h  = time.Hour
+	Width4h  = 4 * time.Hour
+	Width1d  = 24 * time.Hour
+	Width1w  = 7 * 24 * time.Hour
+)
+
+// volume options for ResampleOptions
+const (
+	// market chart total_volumes are rolling 24h figures, the last one of a bucket is the one to keep
+	VolumeLast = "last"
+	// for series of per-interval volume
+	VolumeSum = "sum"
+)
+
+type ResampleOptions struct {
+	// ex => Width15m, widths of a day and above are calendar days in Location
+	Width time.Duration
+	// nil for UTC
+	Location *time.Location
+	// offset of the session start from midnight, ex => 8 * time.Hour
+	SessionStart time.Duration
+	// first day of Width1w buckets, zero value is Sunday
+	WeekStart time.Weekday
+	// ex => VolumeLast, empty for VolumeLast
+	VolumeMode string
+}
+
+type Bar struct {
+	Candle
+	// start of the next bar
+	End time.Time
+	// price points in the bar
+	Points int
+	// no price points, prices are left zero rather than forward-filled
+	Gap bool
+}
+
+// builds bars from market chart price points, every bucket from the first to the last point is returned
+// volume points only count when their timestamp matches a price point
+func Resample(prices, volumes []SeriesPoint, opt ResampleOptions) ([]Bar, error) {
+	if opt.Width <= 0 {
+		return nil, errors.New("resample width must be positive")
+	}
+	if opt.Location == nil {
+		opt.Location = time.UTC
+	}
+	if len(prices) == 0 {
+		return nil, nil
+	}
+	points := make([]SeriesPoint, len(prices))
+	copy(points, prices)
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Time.Before(points[j].Time) })
+	volumeAt := make(map[int64]decimal.Decimal, len(volumes))
+	for _, v := range volumes {
+		volumeAt[v.Time.UnixNano()] = v.Value
+	}
+
+	var out []Bar
+	start := bucketStart(points[0].Time, opt)
+	cur := Bar{Candle: Candle{Time: start}, End: bucketEnd(start, opt)}
+	for _, p := range points {
+		for !p.Time.Before(cur.End) {
+			out = append(out, finishBar(cur))
+			cur = Bar{Candle: Candle{Time: cur.End}, End: bucketEnd(cur.End, opt)}
+		}
+		if cur.Points == 0 {
+			cur.Open, cur.High, cur.Low = p.Value, p.Value, p.Value
+		}
+		if p.Value.GreaterThan(cur.High) {
+			cur.High = p.Value
+		}
+		if p.Value.LessThan(cur.Low) {
+			cur.Low = p.Value
+		}
+		cur.Close = p.Value
+		cur.Points++
+		if v, ok := volumeAt[p.Time.UnixNano()]; ok {
+			if opt.VolumeMode == VolumeSum {
+				cur.Volume = cur.Volume.Add(v)
+			} else {
+				cur.Volume = v
+			}
+		}
+	}
+	out = append(out, finishBar(cur))
+	return out, nil
+}
+
+func finishBar(b Bar) Bar {
+	b.Gap = b.Points == 0
+	return b
+}
+
+// session day containing t, in opt.Location
+func sessionDay(t time.Time, opt ResampleOptions) time.Time {
+	local := t.In(opt.Location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, opt.Location).Add(opt.SessionStart)
+	if local.Before(day) {
+		day = time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, opt.Location).Add(opt.SessionStart)
+	}
+	return day
+}
+
+func bucketStart(t time.Time, opt ResampleOptions) time.Time {
+	day := sessionDay(t, opt)
+	switch {
+	case opt.Width >= Width1w:
+		back := (int(day.Weekday()) - int(opt.WeekStart) + 7) % 7
+		return addDays(day, -back, opt)
+	case opt.Width >= Width1d:
+		return day
+	default:
+		n := t.Sub(day) / opt.Width
+		return day.Add(n * opt.Width)
+	}
+}
+
+func bucketEnd(start time.Time, opt ResampleOptions) time.Time {
+	switch {
+	case opt.Width >= Width1d:
+		return addDays(start, int(opt.Width/Width1d), opt)
+	default:
+		end := start.Add(opt.Width)
+		// intraday buckets restart at each session start
+		if next := addDays(sessionDay(start, opt), 1, opt); end.After(next) {
+			return next
+		}
+		return end
+	}
+}
+
+// calendar days, so dst days stay aligned to the session start
+func addDays(t time.Time, n int, opt ResampleOptions) time.Time {
+	local := t.In(opt.Location)
+	return time.Date(local.Year(), local.Month(), local.Day()+n, 0, 0, 0, 0, opt.Location).Add(opt.SessionStart)
+}
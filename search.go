@@ -0,0 +1,125 @@
+package coingeckoapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// trending only changes every few minutes, no need to ask more often
+const trendingTTL = 5 * time.Minute
+
+type SearchResponse struct {
+	Coins []struct {
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		APISymbol     string `json:"api_symbol"`
+		Symbol        string `json:"symbol"`
+		MarketCapRank int    `json:"market_cap_rank"`
+		Thumb         string `json:"thumb"`
+		Large         string `json:"large"`
+	} `json:"coins"`
+	Exchanges []struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		MarketType string `json:"market_type"`
+		Thumb      string `json:"thumb"`
+		Large      string `json:"large"`
+	} `json:"exchanges"`
+	Categories []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"categories"`
+	Nfts []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Symbol string `json:"symbol"`
+		Thumb  string `json:"thumb"`
+	} `json:"nfts"`
+}
+
+type TrendingResponse struct {
+	Coins []struct {
+		Item struct {
+			ID            string          `json:"id"`
+			CoinID        int             `json:"coin_id"`
+			Name          string          `json:"name"`
+			Symbol        string          `json:"symbol"`
+			MarketCapRank int             `json:"market_cap_rank"`
+			Thumb         string          `json:"thumb"`
+			Small         string          `json:"small"`
+			Large         string          `json:"large"`
+			Slug          string          `json:"slug"`
+			PriceBtc      decimal.Decimal `json:"price_btc"`
+			Score         int             `json:"score"`
+		} `json:"item"`
+	} `json:"coins"`
+	Nfts []struct {
+		ID                            string          `json:"id"`
+		Name                          string          `json:"name"`
+		Symbol                        string          `json:"symbol"`
+		Thumb                         string          `json:"thumb"`
+		NftContractID                 int             `json:"nft_contract_id"`
+		NativeCurrencySymbol          string          `json:"native_currency_symbol"`
+		FloorPriceInNativeCurrency    decimal.Decimal `json:"floor_price_in_native_currency"`
+		FloorPrice24hPercentageChange decimal.Decimal `json:"floor_price_24h_percentage_change"`
+	} `json:"nfts"`
+	Categories []struct {
+		ID                int             `json:"id"`
+		Name              string          `json:"name"`
+		MarketCap1hChange decimal.Decimal `json:"market_cap_1h_change"`
+		Slug              string          `json:"slug"`
+		CoinsCount        int             `json:"coins_count"`
+	} `json:"categories"`
+}
+
+// query ex => bitcoin, btc
+func (b *Client) Search(query string) (*SearchResponse, error) {
+	type opt struct {
+		Query string `url:"query"`
+	}
+	input := opt{
+		Query: query,
+	}
+	res, err := b.do("spot", http.MethodGet, "search", input, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := SearchResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// cached inside the client for trendingTTL
+func (b *Client) Trending() (*TrendingResponse, error) {
+	b.trendingMu.Lock()
+	defer b.trendingMu.Unlock()
+	if b.trending != nil && time.Since(b.trendingAt) < trendingTTL {
+		return b.trending.clone(), nil
+	}
+	res, err := b.do("spot", http.MethodGet, "search/trending", nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := TrendingResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	b.trending = &result
+	b.trendingAt = time.Now()
+	return result.clone(), nil
+}
+
+// callers get their own slices, so sorting a result never touches the cache
+func (t *TrendingResponse) clone() *TrendingResponse {
+	out := *t
+	out.Coins = append(out.Coins[:0:0], t.Coins...)
+	out.Nfts = append(out.Nfts[:0:0], t.Nfts...)
+	out.Categories = append(out.Categories[:0:0], t.Categories...)
+	return &out
+}
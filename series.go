@@ -0,0 +1,66 @@
+package coingeckoapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/shopspring/decimal"
+)
+
+// one [timestamp ms, value] pair of a chart endpoint
+type SeriesPoint struct {
+	Time  time.Time
+	Value decimal.Decimal
+}
+
+func (p *SeriesPoint) UnmarshalJSON(data []byte) error {
+	var raw []jsoniter.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return errors.New(fmt.Sprintf("unable to parse series point: %s", string(data)))
+	}
+	var ms float64
+	if err := json.Unmarshal(raw[0], &ms); err != nil {
+		return err
+	}
+	p.Time = time.Unix(0, int64(ms)*int64(time.Millisecond))
+	return p.Value.UnmarshalJSON(raw[1])
+}
+
+func (p SeriesPoint) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("[%d,%s]", p.Time.UnixNano()/int64(time.Millisecond), p.Value.String())), nil
+}
+
+// Volume is zero when the source has no volume
+type Candle struct {
+	Time   time.Time
+	Open   decimal.Decimal
+	High   decimal.Decimal
+	Low    decimal.Decimal
+	Close  decimal.Decimal
+	Volume decimal.Decimal
+}
+
+// row is [timestamp, open, high, low, close(, volume)], unit scales the timestamp to nanoseconds
+func candleFromRow(row []jsoniter.RawMessage, unit time.Duration) (Candle, error) {
+	out := Candle{}
+	if len(row) < 5 {
+		return out, errors.New(fmt.Sprintf("unable to parse candle, %d fields", len(row)))
+	}
+	var ts float64
+	if err := json.Unmarshal(row[0], &ts); err != nil {
+		return out, err
+	}
+	out.Time = time.Unix(0, int64(ts)*int64(unit))
+	fields := []*decimal.Decimal{&out.Open, &out.High, &out.Low, &out.Close, &out.Volume}
+	for i := 1; i < len(row) && i <= len(fields); i++ {
+		if err := fields[i-1].UnmarshalJSON(row[i]); err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
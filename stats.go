@@ -0,0 +1,91 @@
+package coingeckoapi
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// how far back the upstream counters look
+const statsWindow = 5 * time.Minute
+
+type RequestCounts struct {
+	Requests int `json:"requests"`
+	// transport errors and non 200 responses, 429 included
+	Errors      int `json:"errors"`
+	RateLimited int `json:"rate_limited"`
+}
+
+type statsBucket struct {
+	minute int64
+	counts RequestCounts
+}
+
+// upstream calls counted in one minute buckets
+type requestStats struct {
+	mu      sync.Mutex
+	buckets []statsBucket
+}
+
+func newRequestStats() *requestStats {
+	return &requestStats{
+		buckets: make([]statsBucket, int(statsWindow/time.Minute)),
+	}
+}
+
+// status 0 means the request never got a response
+func (s *requestStats) record(status int) {
+	minute := time.Now().Unix() / 60
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket := &s.buckets[minute%int64(len(s.buckets))]
+	if bucket.minute != minute {
+		*bucket = statsBucket{minute: minute}
+	}
+	bucket.counts.Requests++
+	if status != http.StatusOK {
+		bucket.counts.Errors++
+	}
+	if status == http.StatusTooManyRequests {
+		bucket.counts.RateLimited++
+	}
+}
+
+func (s *requestStats) recent() RequestCounts {
+	minute := time.Now().Unix() / 60
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := RequestCounts{}
+	for _, bucket := range s.buckets {
+		if minute-bucket.minute >= int64(len(s.buckets)) {
+			continue
+		}
+		out.Requests += bucket.counts.Requests
+		out.Errors += bucket.counts.Errors
+		out.RateLimited += bucket.counts.RateLimited
+	}
+	return out
+}
+
+// upstream calls made by this client within the last statsWindow
+func (c *Client) RecentRequests() RequestCounts {
+	return c.stats.recent()
+}
+
+// one finished upstream call, passed to the observer set with SetRequestObserver
+type RequestInfo struct {
+	// "spot" or "onchain"
+	Product string
+	// ex => simple/price, coins/bitcoin/ohlc
+	Path string
+	// 0 when the request never got a response
+	Status  int
+	Latency time.Duration
+	Err     error
+}
+
+// fn is called after every upstream call, from the calling goroutine, so it must be safe for concurrent use
+// set it before the client is shared, nil removes it
+func (c *Client) SetRequestObserver(fn func(RequestInfo)) {
+	c.observer = fn
+}
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+)
+
+// how far back the auto granularity still returns 5 minute points
+const minute5Window = 24 * time.Hour
+
+// longest range per call that still gets the granularity from coingecko
+func chunkSpan(g Granularity) time.Duration {
+	switch g {
+	case Minute5:
+		return 24 * time.Hour
+	case Hourly:
+		return 90 * 24 * time.Hour
+	default:
+		return 365 * 24 * time.Hour
+	}
+}
+
+// fills what the store is missing between From and To with market_chart/range calls
+// ranges are marked fetched one chunk at a time, so a rerun resumes where it stopped
+type Backfill struct {
+	Client      *coingeckoapi.Client
+	Store       *Store
+	CoinIDs     []string
+	Vs          []string
+	Granularity Granularity
+	From        time.Time
+	// zero for now
+	To time.Time
+	// gap between calls, zero for coingeckoapi.DefaultCallInterval
+	Interval time.Duration
+	// called after each stored chunk, can be nil
+	Progress func(key Key, chunk Coverage, rows int)
+}
+
+func (b *Backfill) Run(ctx context.Context) error {
+	if b.Granularity.Duration() == 0 {
+		return fmt.Errorf("invalid granularity: %q", b.Granularity)
+	}
+	now := time.Now()
+	to := b.To
+	if to.IsZero() {
+		to = now
+	}
+	// without interval=5m older days come back hourly, refuse rather than store them under the 5m key
+	if b.Granularity == Minute5 && b.Client.Plan() != coingeckoapi.PlanPro {
+		// same minute of slack on both ends, a From of now-24h taken a moment before Run passes
+		if now.Sub(to) > time.Minute || b.From.Before(now.Add(-minute5Window-time.Minute)) {
+			return fmt.Errorf("%s backfill beyond the last day needs the pro plan", Minute5)
+		}
+	}
+	interval := b.Interval
+	if interval <= 0 {
+		interval = coingeckoapi.DefaultCallInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	first := true
+
+	for _, coinID := range b.CoinIDs {
+		for _, vs := range b.Vs {
+			key := Key{CoinID: coinID, Vs: vs, Granularity: b.Granularity}
+			gaps, err := b.Store.Gaps(key, b.From, to)
+			if err != nil {
+				return err
+			}
+			for _, gap := range gaps {
+				for _, chunk := range splitRange(gap, chunkSpan(b.Granularity)) {
+					if !first {
+						select {
+						case <-ctx.Done():
+							return ctx.Err()
+						case <-ticker.C:
+						}
+					}
+					first = false
+					n, err := b.fetch(ctx, key, chunk)
+					if err != nil {
+						return fmt.Errorf("%s %s - %s: %w", key, chunk.From.Format(time.RFC3339), chunk.To.Format(time.RFC3339), err)
+					}
+					if b.Progress != nil {
+						b.Progress(key, chunk, n)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Backfill) fetch(ctx context.Context, key Key, chunk Coverage) (int, error) {
+	from := chunk.From
+	// daily data only comes back for ranges above 90 days
+	if key.Granularity == Daily && chunk.To.Sub(from) <= 91*24*time.Hour {
+		from = chunk.To.Add(-91 * 24 * time.Hour)
+	}
+	interval := ""
+	if key.Granularity == Minute5 && b.Client.Plan() == coingeckoapi.PlanPro {
+		interval = coingeckoapi.ChartInterval5m
+	}
+	chart, err := b.Client.MarketChartRangeContext(ctx, key.CoinID, key.Vs, from, chunk.To, interval)
+	if err != nil {
+		return 0, err
+	}
+	rows := chartRows(chart, key.Granularity.Duration(), chunk)
+	if err := b.Store.Append(key, rows, chunk); err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+// merges the three series by timestamp, first point per bucket inside chunk
+func chartRows(chart *coingeckoapi.MarketChartResponse, bucket time.Duration, chunk Coverage) []Row {
+	byTime := make(map[int64]*Row)
+	var order []int64
+	get := func(t time.Time) *Row {
+		ms := toMs(t)
+		r, ok := byTime[ms]
+		if !ok {
+			r = &Row{Time: t}
+			byTime[ms] = r
+			order = append(order, ms)
+		}
+		return r
+	}
+	for _, p := range chart.Prices {
+		get(p.Time).Price = p.Value
+	}
+	for _, p := range chart.MarketCaps {
+		get(p.Time).MarketCap = p.Value
+	}
+	for _, p := range chart.TotalVolumes {
+		get(p.Time).Volume = p.Value
+	}
+
+	seen := make(map[int64]bool)
+	out := make([]Row, 0, len(order))
+	for _, ms := range order {
+		r := byTime[ms]
+		if r.Time.Before(chunk.From) || r.Time.After(chunk.To) {
+			continue
+		}
+		b := r.Time.Truncate(bucket).UnixNano()
+		if seen[b] {
+			continue
+		}
+		seen[b] = true
+		out = append(out, *r)
+	}
+	return out
+}
+
+func splitRange(r Coverage, span time.Duration) []Coverage {
+	var out []Coverage
+	for from := r.From; from.Before(r.To); from = from.Add(span) {
+		to := from.Add(span)
+		if to.After(r.To) {
+			to = r.To
+		}
+		out = append(out, Coverage{From: from, To: to})
+	}
+	return out
+}
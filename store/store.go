@@ -0,0 +1,442 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	coingeckoapi "github.com/dpong/Coingecko_RESTapi"
+	"github.com/shopspring/decimal"
+)
+
+// one directory per key, rows go to one file per period and the fetched
+// ranges to a coverage file, lines are appended and never rewritten
+//
+//	<coin>/<vs>/<granularity>/<period>.log    p,<time ms>,<price>,<market cap>,<volume>
+//	<coin>/<vs>/<granularity>/coverage.log    c,<from ms>,<to ms>
+//
+// p lines are data rows, c lines mark a range as fetched even if it had no rows.
+// rows can arrive in any order, reads sort them and the last row of a timestamp wins.
+// a period is a month for Minute5 and a year otherwise, so a range read only
+// parses the files it overlaps.
+const (
+	lineRow      = "p"
+	lineCoverage = "c"
+	fileExt      = ".log"
+	coverageFile = "coverage" + fileExt
+)
+
+type Granularity string
+
+const (
+	Minute5 Granularity = "5m"
+	Hourly  Granularity = "hourly"
+	Daily   Granularity = "daily"
+)
+
+// bucket size of the granularity
+func (g Granularity) Duration() time.Duration {
+	switch g {
+	case Minute5:
+		return 5 * time.Minute
+	case Hourly:
+		return time.Hour
+	case Daily:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// utc period holding t, start inclusive and end exclusive
+func (g Granularity) period(t time.Time) (name string, start, end time.Time) {
+	t = t.UTC()
+	if g == Minute5 {
+		start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start.Format("2006-01"), start, start.AddDate(0, 1, 0)
+	}
+	start = time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	return start.Format("2006"), start, start.AddDate(1, 0, 0)
+}
+
+// start of a period file name, false for other files
+func (g Granularity) parsePeriod(name string) (time.Time, bool) {
+	layout := "2006"
+	if g == Minute5 {
+		layout = "2006-01"
+	}
+	t, err := time.Parse(layout, name)
+	return t, err == nil
+}
+
+type Key struct {
+	CoinID      string
+	Vs          string
+	Granularity Granularity
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%s/%s/%s", k.CoinID, k.Vs, k.Granularity)
+}
+
+type Row struct {
+	Time      time.Time
+	Price     decimal.Decimal
+	MarketCap decimal.Decimal
+	Volume    decimal.Decimal
+}
+
+// fields for Series
+const (
+	FieldPrice     = "price"
+	FieldMarketCap = "market_cap"
+	FieldVolume    = "volume"
+)
+
+// a fetched time range, both ends inclusive
+type Coverage struct {
+	From time.Time
+	To   time.Time
+}
+
+type Store struct {
+	dir string
+
+	mu    sync.Mutex
+	locks map[Key]*sync.Mutex
+}
+
+// dir is created if missing
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Store{
+		dir:   dir,
+		locks: make(map[Key]*sync.Mutex),
+	}, nil
+}
+
+func (s *Store) keyDir(key Key) string {
+	return filepath.Join(s.dir, key.CoinID, key.Vs, string(key.Granularity))
+}
+
+func (s *Store) periodPath(key Key, period string) string {
+	return filepath.Join(s.keyDir(key), period+fileExt)
+}
+
+func (s *Store) coveragePath(key Key) string {
+	return filepath.Join(s.keyDir(key), coverageFile)
+}
+
+func (s *Store) lock(key Key) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		s.locks[key] = l
+	}
+	return l
+}
+
+// appends rows and then marks cov as fetched, cov zero means no mark
+// the mark goes last so an interrupted append gets fetched again
+func (s *Store) Append(key Key, rows []Row, cov Coverage) error {
+	if key.CoinID == "" || key.Vs == "" || key.Granularity.Duration() == 0 {
+		return errors.New(fmt.Sprintf("invalid key: %s", key))
+	}
+	l := s.lock(key)
+	l.Lock()
+	defer l.Unlock()
+
+	if err := os.MkdirAll(s.keyDir(key), 0o755); err != nil {
+		return err
+	}
+	byPeriod := make(map[string][]string)
+	var periods []string
+	for _, r := range rows {
+		name, _, _ := key.Granularity.period(r.Time)
+		if _, ok := byPeriod[name]; !ok {
+			periods = append(periods, name)
+		}
+		byPeriod[name] = append(byPeriod[name], fmt.Sprintf("%s,%d,%s,%s,%s\n", lineRow, toMs(r.Time), r.Price, r.MarketCap, r.Volume))
+	}
+	for _, name := range periods {
+		if err := appendLines(s.periodPath(key, name), byPeriod[name]); err != nil {
+			return err
+		}
+	}
+	if !cov.From.IsZero() || !cov.To.IsZero() {
+		line := fmt.Sprintf("%s,%d,%d\n", lineCoverage, toMs(cov.From), toMs(cov.To))
+		return appendLines(s.coveragePath(key), []string{line})
+	}
+	return nil
+}
+
+// appends and syncs, after dropping a torn last line
+func appendLines(path string, lines []string) error {
+	if err := repairTail(path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, line := range lines {
+		w.WriteString(line)
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// rows with from <= time <= to, sorted by time
+func (s *Store) Range(key Key, from, to time.Time) ([]Row, error) {
+	if to.Before(from) {
+		return nil, nil
+	}
+	l := s.lock(key)
+	l.Lock()
+	defer l.Unlock()
+
+	entries, err := os.ReadDir(s.keyDir(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []Row
+	// names sort by time, so the periods come in order
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), fileExt)
+		if e.Name() == coverageFile || name == e.Name() {
+			continue
+		}
+		start, ok := key.Granularity.parsePeriod(name)
+		if !ok {
+			continue
+		}
+		_, _, end := key.Granularity.period(start)
+		if !end.After(from) || start.After(to) {
+			continue
+		}
+		rows, _, err := readLog(s.periodPath(key, name))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		first := sort.Search(len(rows), func(i int) bool { return !rows[i].Time.Before(from) })
+		last := sort.Search(len(rows), func(i int) bool { return rows[i].Time.After(to) })
+		if first < last {
+			out = append(out, rows[first:last]...)
+		}
+	}
+	return out, nil
+}
+
+// one field of Range as a series, field ex => FieldPrice
+func (s *Store) Series(key Key, field string, from, to time.Time) ([]coingeckoapi.SeriesPoint, error) {
+	rows, err := s.Range(key, from, to)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]coingeckoapi.SeriesPoint, 0, len(rows))
+	for _, r := range rows {
+		p := coingeckoapi.SeriesPoint{Time: r.Time}
+		switch field {
+		case FieldPrice:
+			p.Value = r.Price
+		case FieldMarketCap:
+			p.Value = r.MarketCap
+		case FieldVolume:
+			p.Value = r.Volume
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown field: %s", field))
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// fetched ranges, merged and sorted
+func (s *Store) Coverage(key Key) ([]Coverage, error) {
+	l := s.lock(key)
+	l.Lock()
+	defer l.Unlock()
+
+	_, cov, err := readLog(s.coveragePath(key))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	return cov, nil
+}
+
+// parts of from - to not fetched yet, sorted
+func (s *Store) Gaps(key Key, from, to time.Time) ([]Coverage, error) {
+	cov, err := s.Coverage(key)
+	if err != nil {
+		return nil, err
+	}
+	var out []Coverage
+	cursor := from
+	for _, c := range cov {
+		if !c.To.After(cursor) {
+			continue
+		}
+		if !c.From.Before(to) {
+			break
+		}
+		if c.From.After(cursor) {
+			out = append(out, Coverage{From: cursor, To: c.From})
+		}
+		cursor = c.To
+	}
+	if cursor.Before(to) {
+		out = append(out, Coverage{From: cursor, To: to})
+	}
+	return out, nil
+}
+
+// rows sorted and coverage merged, a missing file reads as empty
+func readLog(path string) ([]Row, []Coverage, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	byTime := make(map[int64]Row)
+	var cov []Coverage
+	r := bufio.NewReader(f)
+	for n := 1; ; n++ {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			// torn last line of an interrupted append
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		fields := strings.Split(strings.TrimSuffix(line, "\n"), ",")
+		switch {
+		case fields[0] == lineRow && len(fields) == 5:
+			row, err := parseRow(fields[1:])
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s line %d: %w", filepath.Base(path), n, err)
+			}
+			byTime[toMs(row.Time)] = row
+		case fields[0] == lineCoverage && len(fields) == 3:
+			from, err1 := strconv.ParseInt(fields[1], 10, 64)
+			to, err2 := strconv.ParseInt(fields[2], 10, 64)
+			if err1 != nil || err2 != nil {
+				return nil, nil, errors.New(fmt.Sprintf("%s line %d: bad coverage", filepath.Base(path), n))
+			}
+			cov = append(cov, Coverage{From: fromMs(from), To: fromMs(to)})
+		default:
+			return nil, nil, errors.New(fmt.Sprintf("%s line %d: unknown record", filepath.Base(path), n))
+		}
+	}
+
+	rows := make([]Row, 0, len(byTime))
+	for _, row := range byTime {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Time.Before(rows[j].Time) })
+	return rows, mergeCoverage(cov), nil
+}
+
+func parseRow(fields []string) (Row, error) {
+	ms, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return Row{}, err
+	}
+	row := Row{Time: fromMs(ms)}
+	values := []*decimal.Decimal{&row.Price, &row.MarketCap, &row.Volume}
+	for i, v := range values {
+		*v, err = decimal.NewFromString(fields[i+1])
+		if err != nil {
+			return Row{}, err
+		}
+	}
+	return row, nil
+}
+
+func mergeCoverage(cov []Coverage) []Coverage {
+	if len(cov) == 0 {
+		return nil
+	}
+	sort.Slice(cov, func(i, j int) bool { return cov[i].From.Before(cov[j].From) })
+	out := []Coverage{cov[0]}
+	for _, c := range cov[1:] {
+		last := &out[len(out)-1]
+		if c.From.After(last.To) {
+			out = append(out, c)
+			continue
+		}
+		if c.To.After(last.To) {
+			last.To = c.To
+		}
+	}
+	return out
+}
+
+// drops a torn last line so new lines start clean
+func repairTail(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	if size == 0 {
+		return nil
+	}
+	// a line is far shorter than tailSize
+	const tailSize = 4096
+	offset := size - tailSize
+	if offset < 0 {
+		offset = 0
+	}
+	tail := make([]byte, size-offset)
+	if _, err := f.ReadAt(tail, offset); err != nil {
+		return err
+	}
+	if tail[len(tail)-1] == '\n' {
+		return nil
+	}
+	return os.Truncate(path, offset+int64(bytes.LastIndexByte(tail, '\n')+1))
+}
+
+func toMs(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func fromMs(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
@@ -0,0 +1,99 @@
+package coingeckoapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type PublicTreasuryResponse struct {
+	TotalHoldings      decimal.Decimal `json:"total_holdings"`
+	TotalValueUsd      decimal.Decimal `json:"total_value_usd"`
+	MarketCapDominance decimal.Decimal `json:"market_cap_dominance"`
+	Companies          []struct {
+		Name                    string          `json:"name"`
+		Symbol                  string          `json:"symbol"`
+		Country                 string          `json:"country"`
+		TotalHoldings           decimal.Decimal `json:"total_holdings"`
+		TotalEntryValueUsd      decimal.Decimal `json:"total_entry_value_usd"`
+		TotalCurrentValueUsd    decimal.Decimal `json:"total_current_value_usd"`
+		PercentageOfTotalSupply decimal.Decimal `json:"percentage_of_total_supply"`
+	} `json:"companies"`
+}
+
+type RevaluedHolding struct {
+	Name               string
+	Symbol             string
+	Country            string
+	TotalHoldings      decimal.Decimal
+	TotalEntryValueUsd decimal.Decimal
+	// holdings * price, in quote currency
+	Value decimal.Decimal
+}
+
+type RevaluedTreasury struct {
+	QuoteCurrency string
+	Price         decimal.Decimal
+	TotalHoldings decimal.Decimal
+	TotalValue    decimal.Decimal
+	Companies     []RevaluedHolding
+}
+
+// coinID ex => bitcoin, ethereum
+func (b *Client) PublicTreasury(coinID string) (*PublicTreasuryResponse, error) {
+	url := fmt.Sprintf("companies/public_treasury/%s", coinID)
+	res, err := b.do("spot", http.MethodGet, url, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	result := PublicTreasuryResponse{}
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// holdings valued at price instead of coingecko's snapshot
+func (t *PublicTreasuryResponse) Revalue(quoteCurrency string, price decimal.Decimal) *RevaluedTreasury {
+	out := RevaluedTreasury{
+		QuoteCurrency: strings.ToLower(quoteCurrency),
+		Price:         price,
+		TotalHoldings: t.TotalHoldings,
+		TotalValue:    t.TotalHoldings.Mul(price),
+		Companies:     make([]RevaluedHolding, 0, len(t.Companies)),
+	}
+	for _, c := range t.Companies {
+		out.Companies = append(out.Companies, RevaluedHolding{
+			Name:               c.Name,
+			Symbol:             c.Symbol,
+			Country:            c.Country,
+			TotalHoldings:      c.TotalHoldings,
+			TotalEntryValueUsd: c.TotalEntryValueUsd,
+			Value:              c.TotalHoldings.Mul(price),
+		})
+	}
+	return &out
+}
+
+// treasury revalued at the live simple/price of coinID
+// quoteCurrency ex => usd
+func (b *Client) PublicTreasuryLive(coinID, quoteCurrency string) (*RevaluedTreasury, error) {
+	treasury, err := b.PublicTreasury(coinID)
+	if err != nil {
+		return nil, err
+	}
+	quote := strings.ToLower(quoteCurrency)
+	prices, err := b.SimplePrices([]string{coinID}, []string{quote}, nil)
+	if err != nil {
+		return nil, err
+	}
+	price, ok := prices[coinID].Price[quote]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("no %s price for %s", quote, coinID))
+	}
+	return treasury.Revalue(quote, price), nil
+}
@@ -0,0 +1,183 @@
+package coingeckoapi
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ID is from coins/list endpoint, Vs ex => usd
+type PricePair struct {
+	ID string
+	Vs string
+}
+
+type PriceUpdate struct {
+	Pair PricePair
+	// zero on the first observation of a pair
+	Old    decimal.Decimal
+	New    decimal.Decimal
+	Change decimal.Decimal
+	// coingecko's last_updated_at, poll time if missing
+	Time time.Time
+}
+
+// polls simple/price and emits updates when a price moves
+type Watcher struct {
+	client   *Client
+	interval time.Duration
+	updates  chan PriceUpdate
+	errs     chan error
+
+	mu    sync.Mutex
+	pairs map[PricePair]struct{}
+	last  map[PricePair]decimal.Decimal
+}
+
+// call Run to start polling
+func (b *Client) NewWatcher(interval time.Duration, pairs ...PricePair) (*Watcher, error) {
+	if interval <= 0 {
+		return nil, errors.New("watcher interval must be positive")
+	}
+	w := &Watcher{
+		client:   b,
+		interval: interval,
+		updates:  make(chan PriceUpdate, 64),
+		errs:     make(chan error, 1),
+		pairs:    make(map[PricePair]struct{}),
+		last:     make(map[PricePair]decimal.Decimal),
+	}
+	w.Add(pairs...)
+	return w, nil
+}
+
+// closed when Run returns
+func (w *Watcher) Updates() <-chan PriceUpdate {
+	return w.updates
+}
+
+// failed simple/price calls, the channel holds one and drops the rest until it is read
+func (w *Watcher) Errors() <-chan error {
+	return w.errs
+}
+
+func (w *Watcher) Add(pairs ...PricePair) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, p := range pairs {
+		w.pairs[normalizePair(p)] = struct{}{}
+	}
+}
+
+func (w *Watcher) Remove(pairs ...PricePair) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, p := range pairs {
+		p = normalizePair(p)
+		delete(w.pairs, p)
+		delete(w.last, p)
+	}
+}
+
+// polls every interval until ctx is done, then closes Updates
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.updates)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		w.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) poll(ctx context.Context) {
+	w.mu.Lock()
+	idSet := make(map[string]struct{})
+	vsSet := make(map[string]struct{})
+	for p := range w.pairs {
+		idSet[p.ID] = struct{}{}
+		vsSet[p.Vs] = struct{}{}
+	}
+	w.mu.Unlock()
+	if len(idSet) == 0 {
+		return
+	}
+
+	ids := sortedKeys(idSet)
+	vs := sortedKeys(vsSet)
+	opt := &SimplePriceOptions{IncludeLastUpdatedAt: true}
+	// every id with every vs, SimplePricesContext splits the ids for url length
+	prices, err := w.client.SimplePricesContext(ctx, ids, vs, opt)
+	if err != nil {
+		select {
+		case w.errs <- err:
+		default:
+		}
+		return
+	}
+	for _, u := range w.diff(prices) {
+		select {
+		case w.updates <- u:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// updates for watched pairs whose price moved, remembers the new prices
+func (w *Watcher) diff(prices SimplePricesResponse) []PriceUpdate {
+	now := time.Now()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var out []PriceUpdate
+	for id, quote := range prices {
+		for vs, price := range quote.Price {
+			pair := PricePair{ID: id, Vs: vs}
+			if _, ok := w.pairs[pair]; !ok {
+				continue
+			}
+			old, seen := w.last[pair]
+			if seen && old.Equal(price) {
+				continue
+			}
+			w.last[pair] = price
+			at := quote.LastUpdatedAt
+			if at.IsZero() {
+				at = now
+			}
+			out = append(out, PriceUpdate{
+				Pair:   pair,
+				Old:    old,
+				New:    price,
+				Change: price.Sub(old),
+				Time:   at,
+			})
+		}
+	}
+	return out
+}
+
+func normalizePair(p PricePair) PricePair {
+	return PricePair{
+		ID: strings.ToLower(p.ID),
+		Vs: strings.ToLower(p.Vs),
+	}
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}